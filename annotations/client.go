@@ -149,3 +149,37 @@ func (c *Client) DeleteAssignment(ctx context.Context, queueID, userID string) (
 	}
 	return &response, nil
 }
+
+// IterQueues returns an iterator over all annotation queues, fetching pages lazily
+func (c *Client) IterQueues(ctx context.Context, opts ...types.IteratorOption) *types.Iterator[Queue] {
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Queue, types.MetaResponse, error) {
+		response, err := c.ListQueues(ctx, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
+
+// IterQueueItems returns an iterator over all items of the queue matching params, fetching pages lazily.
+// params.Page and params.Limit set the first page and the page size.
+func (c *Client) IterQueueItems(ctx context.Context, queueID string, params *ListQueueItemsParams, opts ...types.IteratorOption) *types.Iterator[QueueItem] {
+	var p ListQueueItemsParams
+	if params != nil {
+		p = *params
+	}
+	if p.Page != nil {
+		opts = append([]types.IteratorOption{types.WithStartPage(*p.Page)}, opts...)
+	}
+	if p.Limit != nil {
+		opts = append([]types.IteratorOption{types.WithPageSize(*p.Limit)}, opts...)
+	}
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]QueueItem, types.MetaResponse, error) {
+		p.Page, p.Limit = &page, &limit
+		response, err := c.ListQueueItems(ctx, queueID, &p)
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
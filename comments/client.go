@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
 )
 
 // Client provides methods for comment operations
@@ -68,3 +69,26 @@ func (c *Client) List(ctx context.Context, params *ListParams) (*ListResponse, e
 	}
 	return &response, nil
 }
+
+// Iter returns an iterator over all comments matching params, fetching pages lazily.
+// params.Page and params.Limit set the first page and the page size.
+func (c *Client) Iter(ctx context.Context, params *ListParams, opts ...types.IteratorOption) *types.Iterator[Comment] {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	if p.Page != nil {
+		opts = append([]types.IteratorOption{types.WithStartPage(*p.Page)}, opts...)
+	}
+	if p.Limit != nil {
+		opts = append([]types.IteratorOption{types.WithPageSize(*p.Limit)}, opts...)
+	}
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Comment, types.MetaResponse, error) {
+		p.Page, p.Limit = &page, &limit
+		response, err := c.List(ctx, &p)
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
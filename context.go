@@ -0,0 +1,86 @@
+package langfuse
+
+import "context"
+
+type traceContextKey struct{}
+
+type spanContextKey struct{}
+
+// ContextWithTrace returns a copy of ctx that carries the trace. Spans started
+// with StartSpan from the returned context are attached to the trace.
+func ContextWithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, trace)
+}
+
+// TraceFromContext returns the trace carried by ctx, or nil
+func TraceFromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceContextKey{}).(*Trace)
+	return trace
+}
+
+// ContextWithSpan returns a copy of ctx that carries the span. Observations
+// started with StartSpan, StartGeneration or RecordEvent from the returned
+// context become children of the span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// StartSpan starts a span parented to the span carried by ctx, or to the trace
+// carried by ctx if there is no span. Without either, it starts a new trace with
+// the same name on the default tracer. The returned context carries the new span,
+// so nested calls, including ones in goroutines that receive a derived context,
+// are parented automatically.
+//
+// Example:
+//
+//	func query(ctx context.Context) error {
+//	    ctx, span := langfuse.StartSpan(ctx, "db-query")
+//	    defer span.End()
+//	    ...
+//	}
+func StartSpan(ctx context.Context, name string, opts ...Option) (context.Context, *Span) {
+	var span *Span
+	if parent := SpanFromContext(ctx); parent != nil {
+		span = parent.StartSpan(name, opts...)
+	} else {
+		trace := TraceFromContext(ctx)
+		if trace == nil {
+			trace = StartTrace(ctx, name)
+			ctx = ContextWithTrace(ctx, trace)
+		}
+		span = trace.StartSpan(name, opts...)
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+// StartGeneration starts a generation parented to the span or trace carried by
+// ctx, like StartSpan.
+func StartGeneration(ctx context.Context, name string, opts ...Option) *Generation {
+	if parent := SpanFromContext(ctx); parent != nil {
+		return parent.StartGeneration(name, opts...)
+	}
+	trace := TraceFromContext(ctx)
+	if trace == nil {
+		trace = StartTrace(ctx, name)
+	}
+	return trace.StartGeneration(name, opts...)
+}
+
+// RecordEvent records an event parented to the span or trace carried by ctx, like StartSpan.
+func RecordEvent(ctx context.Context, name string, opts ...Option) {
+	if parent := SpanFromContext(ctx); parent != nil {
+		parent.Event(name, opts...)
+		return
+	}
+	trace := TraceFromContext(ctx)
+	if trace == nil {
+		trace = StartTrace(ctx, name)
+	}
+	trace.Event(name, opts...)
+}
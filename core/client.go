@@ -26,8 +26,20 @@ type HTTPClient struct {
 	PublicKey  string
 	SecretKey  string
 	HTTPClient *http.Client
+	Retry      RetryPolicy
+	ExportMode ExportMode
 }
 
+// ExportMode selects the wire format used to ingest observations
+type ExportMode string
+
+const (
+	// ExportModeJSON sends observations as JSON to the ingestion API
+	ExportModeJSON ExportMode = "json"
+	// ExportModeOTLP sends observations as OTLP/HTTP protobuf to the OpenTelemetry endpoint
+	ExportModeOTLP ExportMode = "otlp"
+)
+
 // Option is a functional option for configuring the HTTPClient
 type Option func(*HTTPClient)
 
@@ -52,12 +64,20 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithExportMode sets the wire format used to ingest observations
+func WithExportMode(mode ExportMode) Option {
+	return func(c *HTTPClient) {
+		c.ExportMode = mode
+	}
+}
+
 // NewHTTPClient creates a new base HTTP client
 func NewHTTPClient(publicKey, secretKey string, opts ...Option) *HTTPClient {
 	client := &HTTPClient{
-		BaseURL:   DefaultBaseURL,
-		PublicKey: publicKey,
-		SecretKey: secretKey,
+		BaseURL:    DefaultBaseURL,
+		PublicKey:  publicKey,
+		SecretKey:  secretKey,
+		ExportMode: ExportModeJSON,
 		HTTPClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
@@ -70,37 +90,118 @@ func NewHTTPClient(publicKey, secretKey string, opts ...Option) *HTTPClient {
 	return client
 }
 
-// DoRequest performs an HTTP request with authentication
+// DoRequest performs an HTTP request with authentication.
+// Failed requests are retried according to the client's RetryPolicy.
+// Non-2xx responses are returned as *APIError.
 func (c *HTTPClient) DoRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := sonic.Marshal(body)
+		var err error
+		jsonData, err = sonic.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	return c.do(ctx, method, path, "application/json", jsonData, func(resp *http.Response) error {
+		return c.handleResponse(resp, result)
+	})
+}
+
+// DoRawRequest performs an HTTP request with authentication and a pre-encoded body
+// of the given content type, such as an OTLP protobuf payload, and returns the raw
+// response body. Retries and errors are handled like DoRequest.
+func (c *HTTPClient) DoRawRequest(ctx context.Context, method, path, contentType string, data []byte) ([]byte, error) {
+	var body []byte
+	err := c.do(ctx, method, path, contentType, data, func(resp *http.Response) error {
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			return newAPIError(resp, bodyBytes)
+		}
+		var err error
+		if body, err = io.ReadAll(resp.Body); err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+		return nil
+	})
+	return body, err
+}
+
+// do sends the request, retrying according to the client's RetryPolicy, and
+// passes the final response to handle
+func (c *HTTPClient) do(ctx context.Context, method, path, contentType string, data []byte, handle func(*http.Response) error) error {
+	key := idempotencyKey(ctx)
+	canRetry := isIdempotent(method) || key != ""
+
+	for attempt := 1; ; attempt++ {
+		req, err := c.newRequest(ctx, method, path, contentType, data, key)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+
+		if canRetry && attempt < c.Retry.MaxAttempts && ctx.Err() == nil && shouldRetry(resp, err) {
+			wait, ok := retryAfter(resp)
+			if !ok {
+				wait = Backoff(attempt, c.Retry.InitialBackoff, c.Retry.MaxBackoff)
+			} else if c.Retry.MaxBackoff > 0 && wait > c.Retry.MaxBackoff {
+				wait = c.Retry.MaxBackoff
+			}
+			if resp != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+				continue
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("failed to perform request: %w", ctx.Err())
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to perform request: %w", err)
+		}
+		return handle(resp)
+	}
+}
+
+// newRequest builds an authenticated request for a single attempt
+func (c *HTTPClient) newRequest(ctx context.Context, method, path, contentType string, data []byte, idempotencyKey string) (*http.Request, error) {
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
 	// Add Basic Auth
 	auth := base64.StdEncoding.EncodeToString([]byte(c.PublicKey + ":" + c.SecretKey))
 	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to perform request: %w", err)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
 	}
+
+	return req, nil
+}
+
+// handleResponse checks the response status and decodes the body into result
+func (c *HTTPClient) handleResponse(resp *http.Response, result interface{}) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError(resp, bodyBytes)
 	}
 
 	if result != nil && resp.StatusCode != http.StatusNoContent {
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRequestRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	// flaky fails the first n requests with the given status
+	flaky := func(n int32, status int) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= n {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				return
+			}
+			w.Write([]byte(`{"status":"OK"}`))
+		}))
+		t.Cleanup(server.Close)
+		return server, &calls
+	}
+
+	t.Run("retries idempotent requests", func(t *testing.T) {
+		server, calls := flaky(2, http.StatusServiceUnavailable)
+		c := NewHTTPClient("pk", "sk", WithBaseURL(server.URL), WithRetry(policy))
+
+		var out struct{ Status string }
+		if err := c.DoRequest(context.Background(), http.MethodGet, "/", nil, &out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls.Load() != 3 || out.Status != "OK" {
+			t.Errorf("expected success on the third attempt, got %d attempts and status %q", calls.Load(), out.Status)
+		}
+	})
+
+	t.Run("does not retry POST without an idempotency key", func(t *testing.T) {
+		server, calls := flaky(1, http.StatusTooManyRequests)
+		c := NewHTTPClient("pk", "sk", WithBaseURL(server.URL), WithRetry(policy))
+
+		if err := c.DoRequest(context.Background(), http.MethodPost, "/", map[string]string{}, nil); err == nil {
+			t.Fatal("expected an error")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("expected a single attempt, got %d", calls.Load())
+		}
+	})
+
+	t.Run("retries POST with an idempotency key", func(t *testing.T) {
+		server, calls := flaky(1, http.StatusTooManyRequests)
+		c := NewHTTPClient("pk", "sk", WithBaseURL(server.URL), WithRetry(policy))
+
+		ctx := WithIdempotencyKey(context.Background(), "key-1")
+		if err := c.DoRequest(ctx, http.MethodPost, "/", map[string]string{}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls.Load() != 2 {
+			t.Errorf("expected 2 attempts, got %d", calls.Load())
+		}
+	})
+
+	t.Run("caps Retry-After at MaxBackoff", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+		c := NewHTTPClient("pk", "sk", WithBaseURL(server.URL), WithRetry(policy))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := c.DoRequest(ctx, http.MethodGet, "/", nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls.Load() != 2 {
+			t.Errorf("expected 2 attempts, got %d", calls.Load())
+		}
+	})
+
+	t.Run("does not retry other statuses", func(t *testing.T) {
+		server, calls := flaky(1, http.StatusInternalServerError)
+		c := NewHTTPClient("pk", "sk", WithBaseURL(server.URL), WithRetry(policy))
+
+		if err := c.DoRequest(context.Background(), http.MethodGet, "/", nil, nil); err == nil {
+			t.Fatal("expected an error")
+		}
+		if calls.Load() != 1 {
+			t.Errorf("expected a single attempt, got %d", calls.Load())
+		}
+	})
+}
+
+func TestDoRequestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Trace not found","error":"LangfuseNotFoundError"}`))
+	}))
+	defer server.Close()
+
+	c := NewHTTPClient("pk", "sk", WithBaseURL(server.URL))
+	err := c.DoRequest(context.Background(), http.MethodGet, "/api/public/traces/abc", nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Trace not found" {
+		t.Errorf("unexpected status or message: %d %q", apiErr.StatusCode, apiErr.Message)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.Path != "/api/public/traces/abc" || apiErr.RequestID != "req-123" {
+		t.Errorf("unexpected request details: %s %s %s", apiErr.Method, apiErr.Path, apiErr.RequestID)
+	}
+	if !IsNotFound(err) || IsUnauthorized(err) || IsRateLimited(err) {
+		t.Error("sentinel helpers did not match the status code")
+	}
+	if !IsNotFound(fmt.Errorf("wrapped: %w", err)) {
+		t.Error("expected IsNotFound to match a wrapped error")
+	}
+}
+
+func TestWithRetryNormalizesBackoff(t *testing.T) {
+	c := NewHTTPClient("pk", "sk", WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: -1}))
+	if c.Retry.InitialBackoff != time.Second || c.Retry.MaxBackoff != DefaultRetryPolicy().MaxBackoff {
+		t.Errorf("unexpected policy: %+v", c.Retry)
+	}
+
+	c = NewHTTPClient("pk", "sk", WithRetry(RetryPolicy{MaxAttempts: 3}))
+	if c.Retry.InitialBackoff <= 0 || c.Retry.MaxBackoff < c.Retry.InitialBackoff {
+		t.Errorf("unexpected policy: %+v", c.Retry)
+	}
+
+	// Backoff must not panic on an unvalidated policy
+	if d := Backoff(1, 0, -time.Second); d != 0 {
+		t.Errorf("expected no delay, got %v", d)
+	}
+}
@@ -41,6 +41,15 @@ func (c *Client) Get(ctx context.Context, datasetName string) (*Dataset, error)
 	return &response, nil
 }
 
+// Delete deletes a dataset together with its items and runs
+func (c *Client) Delete(ctx context.Context, datasetName string) (*DeleteResponse, error) {
+	var response DeleteResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodDelete, "/api/public/datasets/"+url.PathEscape(datasetName), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
 // List retrieves all datasets with pagination
 func (c *Client) List(ctx context.Context, params *types.PaginationParams) (*ListResponse, error) {
 	path := "/api/public/datasets"
@@ -82,6 +91,49 @@ func (c *Client) GetItem(ctx context.Context, itemID string) (*Item, error) {
 	return &response, nil
 }
 
+// UpsertItem creates the dataset item with the given ID, or updates it if it exists
+func (c *Client) UpsertItem(ctx context.Context, itemID string, req *CreateItemRequest) (*Item, error) {
+	upsert := *req
+	upsert.ID = &itemID
+	return c.CreateItem(ctx, &upsert)
+}
+
+// SetItemStatus changes the status of a dataset item, keeping its other fields
+func (c *Client) SetItemStatus(ctx context.Context, itemID, status string) (*Item, error) {
+	item, err := c.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return c.UpsertItem(ctx, itemID, &CreateItemRequest{
+		DatasetName:         &item.DatasetName,
+		Input:               item.Input,
+		ExpectedOutput:      item.ExpectedOutput,
+		Metadata:            item.Metadata,
+		SourceTraceID:       item.SourceTraceID,
+		SourceObservationID: item.SourceObservationID,
+		Status:              &status,
+	})
+}
+
+// ArchiveItem archives a dataset item, excluding it from new experiments
+func (c *Client) ArchiveItem(ctx context.Context, itemID string) (*Item, error) {
+	return c.SetItemStatus(ctx, itemID, ItemStatusArchived)
+}
+
+// UnarchiveItem makes an archived dataset item active again
+func (c *Client) UnarchiveItem(ctx context.Context, itemID string) (*Item, error) {
+	return c.SetItemStatus(ctx, itemID, ItemStatusActive)
+}
+
+// DeleteItem deletes a dataset item
+func (c *Client) DeleteItem(ctx context.Context, itemID string) (*DeleteResponse, error) {
+	var response DeleteResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodDelete, "/api/public/dataset-items/"+url.PathEscape(itemID), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
 // ListItems retrieves items for a dataset
 func (c *Client) ListItems(ctx context.Context, datasetName string, params *types.PaginationParams) (*ItemListResponse, error) {
 	path := "/api/public/datasets/" + url.PathEscape(datasetName) + "/items"
@@ -146,6 +198,28 @@ func (c *Client) ListRuns(ctx context.Context, datasetName string, params *types
 	return &response, nil
 }
 
+// DeleteRun deletes a dataset run and its run items. The linked traces are kept.
+// The API addresses runs for deletion by dataset and run name; use DeleteRunByID
+// to delete a run by the ID used by GetRun and ListRunItems.
+func (c *Client) DeleteRun(ctx context.Context, datasetName, runName string) (*DeleteResponse, error) {
+	path := "/api/public/datasets/" + url.PathEscape(datasetName) + "/runs/" + url.PathEscape(runName)
+
+	var response DeleteResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodDelete, path, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DeleteRunByID deletes a dataset run by ID, resolving its dataset and run name with GetRun
+func (c *Client) DeleteRunByID(ctx context.Context, runID string) (*DeleteResponse, error) {
+	run, err := c.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return c.DeleteRun(ctx, run.DatasetName, run.Name)
+}
+
 // CreateRunItem creates a new dataset run item
 func (c *Client) CreateRunItem(ctx context.Context, req *CreateRunItemRequest) (*RunItem, error) {
 	var response RunItem
@@ -154,3 +228,110 @@ func (c *Client) CreateRunItem(ctx context.Context, req *CreateRunItemRequest) (
 	}
 	return &response, nil
 }
+
+// ListRunItems retrieves the items of a dataset run
+func (c *Client) ListRunItems(ctx context.Context, runID string, params *types.PaginationParams) (*RunItemListResponse, error) {
+	run, err := c.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return c.listRunItems(ctx, run, params)
+}
+
+// GetRunWithItems retrieves a dataset run together with all of its items
+func (c *Client) GetRunWithItems(ctx context.Context, runID string) (*RunWithItems, error) {
+	run, err := c.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := c.iterRunItems(ctx, run).All()
+	if err != nil {
+		return nil, err
+	}
+	return &RunWithItems{Run: *run, Items: items}, nil
+}
+
+// listRunItems retrieves the items of a resolved dataset run
+func (c *Client) listRunItems(ctx context.Context, run *Run, params *types.PaginationParams) (*RunItemListResponse, error) {
+	query := url.Values{}
+	query.Set("datasetId", run.DatasetID)
+	query.Set("runName", run.Name)
+	if params != nil {
+		if params.Page != nil {
+			query.Set("page", strconv.Itoa(*params.Page))
+		}
+		if params.Limit != nil {
+			query.Set("limit", strconv.Itoa(*params.Limit))
+		}
+	}
+
+	var response RunItemListResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodGet, "/api/public/dataset-run-items?"+query.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Iter returns an iterator over all datasets, fetching pages lazily
+func (c *Client) Iter(ctx context.Context, opts ...types.IteratorOption) *types.Iterator[Dataset] {
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Dataset, types.MetaResponse, error) {
+		response, err := c.List(ctx, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
+
+// IterItems returns an iterator over all items of the dataset, fetching pages lazily
+func (c *Client) IterItems(ctx context.Context, datasetName string, opts ...types.IteratorOption) *types.Iterator[Item] {
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Item, types.MetaResponse, error) {
+		response, err := c.ListItems(ctx, datasetName, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
+
+// IterRuns returns an iterator over all runs of the dataset, fetching pages lazily
+func (c *Client) IterRuns(ctx context.Context, datasetName string, opts ...types.IteratorOption) *types.Iterator[Run] {
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Run, types.MetaResponse, error) {
+		response, err := c.ListRuns(ctx, datasetName, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
+
+// IterRunItems returns an iterator over all items of a dataset run, fetching pages lazily.
+// The run is resolved on the first page request.
+func (c *Client) IterRunItems(ctx context.Context, runID string, opts ...types.IteratorOption) *types.Iterator[RunItem] {
+	var run *Run
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]RunItem, types.MetaResponse, error) {
+		if run == nil {
+			resolved, err := c.GetRun(ctx, runID)
+			if err != nil {
+				return nil, types.MetaResponse{}, err
+			}
+			run = resolved
+		}
+		response, err := c.listRunItems(ctx, run, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
+
+// iterRunItems returns an iterator over all items of a resolved dataset run
+func (c *Client) iterRunItems(ctx context.Context, run *Run, opts ...types.IteratorOption) *types.Iterator[RunItem] {
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]RunItem, types.MetaResponse, error) {
+		response, err := c.listRunItems(ctx, run, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
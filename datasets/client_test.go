@@ -0,0 +1,81 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestArchiveItem(t *testing.T) {
+	var upsert map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/public/dataset-items/item-1":
+			json.NewEncoder(w).Encode(Item{
+				ID:             "item-1",
+				DatasetName:    "qa",
+				Input:          "question",
+				ExpectedOutput: "answer",
+				SourceTraceID:  types.String("trace-1"),
+				Status:         ItemStatusActive,
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/public/dataset-items":
+			json.NewDecoder(r.Body).Decode(&upsert)
+			json.NewEncoder(w).Encode(Item{ID: "item-1", Status: ItemStatusArchived})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	item, err := client.ArchiveItem(context.Background(), "item-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Status != ItemStatusArchived {
+		t.Errorf("expected an archived item, got %q", item.Status)
+	}
+
+	// The upsert must carry the existing fields so they are not cleared
+	expected := map[string]interface{}{
+		"id":             "item-1",
+		"datasetName":    "qa",
+		"input":          "question",
+		"expectedOutput": "answer",
+		"sourceTraceId":  "trace-1",
+		"status":         ItemStatusArchived,
+	}
+	for key, value := range expected {
+		if upsert[key] != value {
+			t.Errorf("expected %s %v in the upsert, got %v", key, value, upsert[key])
+		}
+	}
+}
+
+func TestDeleteRunByID(t *testing.T) {
+	var deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(Run{ID: "run-1", Name: "baseline run", DatasetName: "qa"})
+		case http.MethodDelete:
+			deleted = r.URL.EscapedPath()
+			w.Write([]byte(`{"message":"Dataset run deleted"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	if _, err := client.DeleteRunByID(context.Background(), "run-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != "/api/public/datasets/qa/runs/baseline%20run" {
+		t.Errorf("unexpected delete path: %s", deleted)
+	}
+}
@@ -0,0 +1,288 @@
+package datasets
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/rohitkeshwani07/langfuse-go/scores"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// compareConcurrency is the number of traces whose scores CompareRuns fetches at once
+const compareConcurrency = 4
+
+// ScoreDiff compares the value of a score between two runs for one dataset item.
+// A and B are nil when the run has no numeric or boolean score with that name;
+// Delta is B - A and is only set when both values are present.
+type ScoreDiff struct {
+	A     *float64
+	B     *float64
+	Delta *float64
+}
+
+// ItemComparison compares the results of two runs for one dataset item.
+// A and B hold the run items of each run for the dataset item; a run holds
+// several when the item was run more than once, and none when the item is
+// only part of the other run.
+type ItemComparison struct {
+	DatasetItemID string
+	A             []RunItem
+	B             []RunItem
+	Scores        map[string]ScoreDiff
+}
+
+// ScoreComparison aggregates the values of a score across two runs.
+// Higher values are considered better.
+type ScoreComparison struct {
+	CountA int
+	CountB int
+	MeanA  float64
+	MeanB  float64
+
+	// Paired is the number of items scored in both runs; the fields below only
+	// take these items into account
+	Paired    int
+	MeanDelta float64
+	Improved  int
+	Regressed int
+	Unchanged int
+}
+
+// RunComparison is the result of CompareRuns
+type RunComparison struct {
+	RunA Run
+	RunB Run
+
+	// Items holds one comparison per dataset item, ordered by dataset item ID
+	Items  []ItemComparison
+	Scores map[string]ScoreComparison
+}
+
+// CompareRuns joins the items of two dataset runs by dataset item and compares
+// the scores of their linked traces. Numeric and boolean scores are compared;
+// when a trace has several scores with the same name, or a run has several
+// items for the same dataset item, the mean of their values is used.
+func (c *Client) CompareRuns(ctx context.Context, runAID, runBID string) (*RunComparison, error) {
+	runA, err := c.GetRunWithItems(ctx, runAID)
+	if err != nil {
+		return nil, err
+	}
+	runB, err := c.GetRunWithItems(ctx, runBID)
+	if err != nil {
+		return nil, err
+	}
+
+	traceScores, err := c.traceScores(ctx, append(append([]RunItem(nil), runA.Items...), runB.Items...))
+	if err != nil {
+		return nil, err
+	}
+
+	items := map[string]*ItemComparison{}
+	item := func(id string) *ItemComparison {
+		if items[id] == nil {
+			items[id] = &ItemComparison{DatasetItemID: id, Scores: map[string]ScoreDiff{}}
+		}
+		return items[id]
+	}
+	for _, runItem := range runA.Items {
+		comparison := item(runItem.DatasetItemID)
+		comparison.A = append(comparison.A, runItem)
+	}
+	for _, runItem := range runB.Items {
+		comparison := item(runItem.DatasetItemID)
+		comparison.B = append(comparison.B, runItem)
+	}
+
+	result := &RunComparison{
+		RunA:   runA.Run,
+		RunB:   runB.Run,
+		Items:  make([]ItemComparison, 0, len(items)),
+		Scores: map[string]ScoreComparison{},
+	}
+	for _, comparison := range items {
+		for name, value := range meanScores(comparison.A, traceScores) {
+			value := value
+			diff := comparison.Scores[name]
+			diff.A = &value
+			comparison.Scores[name] = diff
+		}
+		for name, value := range meanScores(comparison.B, traceScores) {
+			value := value
+			diff := comparison.Scores[name]
+			diff.B = &value
+			comparison.Scores[name] = diff
+		}
+		for name, diff := range comparison.Scores {
+			if diff.A != nil && diff.B != nil {
+				delta := *diff.B - *diff.A
+				diff.Delta = &delta
+				comparison.Scores[name] = diff
+			}
+		}
+		result.Items = append(result.Items, *comparison)
+	}
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].DatasetItemID < result.Items[j].DatasetItemID
+	})
+
+	for name := range scoreNames(result.Items) {
+		result.Scores[name] = aggregate(result.Items, name)
+	}
+	return result, nil
+}
+
+// Regressions returns the items whose score decreased by more than tolerance
+func (c *RunComparison) Regressions(name string, tolerance float64) []ItemComparison {
+	var regressions []ItemComparison
+	for _, item := range c.Items {
+		if delta := item.Scores[name].Delta; delta != nil && *delta < -tolerance {
+			regressions = append(regressions, item)
+		}
+	}
+	return regressions
+}
+
+// traceScores fetches the numeric and boolean scores of the traces linked to the
+// run items, averaged per score name
+func (c *Client) traceScores(ctx context.Context, runItems []RunItem) (map[string]map[string]float64, error) {
+	client := scores.NewClient(c.httpClient)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		results  = map[string]map[string]float64{}
+		seen     = map[string]bool{}
+		sem      = make(chan struct{}, compareConcurrency)
+	)
+	for _, runItem := range runItems {
+		traceID := runItem.TraceID
+		if seen[traceID] || traceID == "" {
+			continue
+		}
+		seen[traceID] = true
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		mu.Lock()
+		stop := firstErr != nil
+		mu.Unlock()
+		if stop || ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			sums, counts := map[string]float64{}, map[string]int{}
+			it := client.Iter(ctx, &scores.ListParams{TraceID: &traceID})
+			for it.Next() {
+				score := it.Item()
+				if score.DataType == types.ScoreDataTypeCategorical {
+					continue
+				}
+				if value, ok := scores.FloatValue(score.Value); ok {
+					sums[score.Name] += value
+					counts[score.Name]++
+				}
+			}
+			for name := range sums {
+				sums[name] /= float64(counts[name])
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err := it.Err(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			results[traceID] = sums
+		}()
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// meanScores averages the scores of the traces linked to the run items per score name
+func meanScores(runItems []RunItem, traceScores map[string]map[string]float64) map[string]float64 {
+	sums, counts := map[string]float64{}, map[string]int{}
+	for _, runItem := range runItems {
+		for name, value := range traceScores[runItem.TraceID] {
+			sums[name] += value
+			counts[name]++
+		}
+	}
+	for name := range sums {
+		sums[name] /= float64(counts[name])
+	}
+	return sums
+}
+
+// scoreNames returns the names of the scores present in the comparisons
+func scoreNames(items []ItemComparison) map[string]bool {
+	names := map[string]bool{}
+	for _, item := range items {
+		for name := range item.Scores {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// aggregate summarizes a score across the item comparisons
+func aggregate(items []ItemComparison, name string) ScoreComparison {
+	var (
+		summary              ScoreComparison
+		sumA, sumB, sumDelta float64
+	)
+	for _, item := range items {
+		diff, ok := item.Scores[name]
+		if !ok {
+			continue
+		}
+		if diff.A != nil {
+			summary.CountA++
+			sumA += *diff.A
+		}
+		if diff.B != nil {
+			summary.CountB++
+			sumB += *diff.B
+		}
+		if diff.Delta == nil {
+			continue
+		}
+		summary.Paired++
+		sumDelta += *diff.Delta
+		switch {
+		case *diff.Delta > 0:
+			summary.Improved++
+		case *diff.Delta < 0:
+			summary.Regressed++
+		default:
+			summary.Unchanged++
+		}
+	}
+
+	if summary.CountA > 0 {
+		summary.MeanA = sumA / float64(summary.CountA)
+	}
+	if summary.CountB > 0 {
+		summary.MeanB = sumB / float64(summary.CountB)
+	}
+	if summary.Paired > 0 {
+		summary.MeanDelta = sumDelta / float64(summary.Paired)
+	}
+	return summary
+}
@@ -0,0 +1,113 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/scores"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestCompareRuns(t *testing.T) {
+	runItems := map[string][]RunItem{
+		"baseline":  {{DatasetItemID: "item-1", TraceID: "a1"}, {DatasetItemID: "item-2", TraceID: "a2"}, {DatasetItemID: "item-1", TraceID: "a3"}},
+		"candidate": {{DatasetItemID: "item-1", TraceID: "b1"}, {DatasetItemID: "item-2", TraceID: "b2"}, {DatasetItemID: "item-3", TraceID: "b3"}},
+	}
+	traceScores := map[string][]scores.Score{
+		"a1": {{Name: "accuracy", Value: 1.0, DataType: types.ScoreDataTypeNumeric}},
+		"a2": {{Name: "accuracy", Value: 1.0, DataType: types.ScoreDataTypeNumeric}},
+		"a3": {{Name: "accuracy", Value: 0.0, DataType: types.ScoreDataTypeNumeric}},
+		"b1": {{Name: "accuracy", Value: 1.0, DataType: types.ScoreDataTypeNumeric}, {Name: "tone", Value: 0.0, StringValue: types.String("rude"), DataType: types.ScoreDataTypeCategorical}},
+		"b2": {{Name: "accuracy", Value: 0.5, DataType: types.ScoreDataTypeNumeric}, {Name: "accuracy", Value: 0.0, DataType: types.ScoreDataTypeNumeric}},
+		"b3": {{Name: "accuracy", Value: 1.0, DataType: types.ScoreDataTypeNumeric}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/public/dataset-runs/"):
+			name := strings.TrimPrefix(r.URL.Path, "/api/public/dataset-runs/")
+			json.NewEncoder(w).Encode(Run{ID: name, Name: name, DatasetID: "dataset-1"})
+		case r.URL.Path == "/api/public/dataset-run-items":
+			if query.Get("datasetId") != "dataset-1" {
+				t.Errorf("expected the datasetId filter, got %q", r.URL.RawQuery)
+			}
+			items := runItems[query.Get("runName")]
+			json.NewEncoder(w).Encode(RunItemListResponse{Data: items, Meta: types.MetaResponse{Page: 1, TotalPages: 1, TotalItems: len(items)}})
+		case r.URL.Path == "/api/public/scores":
+			data := traceScores[query.Get("traceId")]
+			json.NewEncoder(w).Encode(scores.ListResponse{Data: data, Meta: types.MetaResponse{Page: 1, TotalPages: 1}})
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	comparison, err := client.CompareRuns(context.Background(), "baseline", "candidate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(comparison.Items) != 3 || len(comparison.Items[2].A) != 0 || len(comparison.Items[2].B) != 1 {
+		t.Fatalf("expected item-3 to only be part of the candidate run, got %+v", comparison.Items)
+	}
+	// Both baseline runs of item-1 are kept and their scores averaged
+	if item := comparison.Items[0]; len(item.A) != 2 || item.Scores["accuracy"].Delta == nil || *item.Scores["accuracy"].Delta != 0.5 {
+		t.Errorf("expected item-1 to improve by 0.5 over both baseline runs, got %+v", item)
+	}
+	if diff := comparison.Items[1].Scores["accuracy"]; diff.Delta == nil || *diff.Delta != -0.75 {
+		t.Errorf("expected item-2 to regress by 0.75, got %+v", diff)
+	}
+	if _, ok := comparison.Scores["tone"]; ok {
+		t.Error("expected categorical scores to be left out")
+	}
+
+	accuracy := comparison.Scores["accuracy"]
+	if accuracy.CountA != 2 || accuracy.CountB != 3 || accuracy.Paired != 2 || accuracy.Regressed != 1 || accuracy.Improved != 1 {
+		t.Errorf("unexpected accuracy summary: %+v", accuracy)
+	}
+	if accuracy.MeanDelta != -0.125 {
+		t.Errorf("expected a mean delta of -0.125, got %v", accuracy.MeanDelta)
+	}
+	if regressions := comparison.Regressions("accuracy", 0.1); len(regressions) != 1 || regressions[0].DatasetItemID != "item-2" {
+		t.Errorf("unexpected regressions: %+v", regressions)
+	}
+}
+
+func TestCompareRunsStopsAfterError(t *testing.T) {
+	var runItems []RunItem
+	for i := 0; i < 20; i++ {
+		runItems = append(runItems, RunItem{DatasetItemID: fmt.Sprintf("item-%d", i), TraceID: fmt.Sprintf("trace-%d", i)})
+	}
+
+	var scoreRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/public/dataset-runs/"):
+			json.NewEncoder(w).Encode(Run{ID: "run", Name: "run", DatasetID: "dataset-1"})
+		case r.URL.Path == "/api/public/dataset-run-items":
+			json.NewEncoder(w).Encode(RunItemListResponse{Data: runItems, Meta: types.MetaResponse{Page: 1, TotalPages: 1, TotalItems: len(runItems)}})
+		case r.URL.Path == "/api/public/scores":
+			scoreRequests.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	if _, err := client.CompareRuns(context.Background(), "baseline", "candidate"); err == nil {
+		t.Fatal("expected the score error to be returned")
+	}
+	// Only the fetches started before the first error are sent
+	if n := scoreRequests.Load(); n > compareConcurrency {
+		t.Errorf("expected at most %d score requests, got %d", compareConcurrency, n)
+	}
+}
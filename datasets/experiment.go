@@ -0,0 +1,393 @@
+package datasets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/scores"
+	"github.com/rohitkeshwani07/langfuse-go/traces"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// DefaultExperimentConcurrency is the number of items an experiment processes at once
+const DefaultExperimentConcurrency = 4
+
+// Task runs the application under test on a dataset item and returns its output.
+// The context is the one returned by the experiment tracer's StartTrace, so with
+// langfuse.ExperimentTracer the spans and generations the task starts with
+// langfuse.StartSpan and langfuse.StartGeneration are attached to the item's trace.
+type Task func(ctx context.Context, item Item) (interface{}, error)
+
+// Evaluator scores the output of a task for a dataset item.
+// Returning a nil Evaluation records no score.
+type Evaluator func(ctx context.Context, item Item, output interface{}) (*Evaluation, error)
+
+// Evaluation is a score produced by an Evaluator. Boolean values are sent as
+// 0 or 1 with the BOOLEAN data type unless another data type is set.
+type Evaluation struct {
+	Name     string               `json:"name"`
+	Value    interface{}          `json:"value"`
+	Comment  *string              `json:"comment,omitempty"`
+	DataType *types.ScoreDataType `json:"dataType,omitempty"`
+}
+
+// ExperimentTracer records the traces of an experiment. langfuse.ExperimentTracer
+// adapts a *langfuse.Tracer to it.
+type ExperimentTracer interface {
+	// StartTrace starts the trace of an item and returns a context that carries it
+	StartTrace(ctx context.Context, name string, input interface{}, metadata map[string]interface{}) (context.Context, ExperimentTrace)
+	// Flush sends the traces recorded so far
+	Flush(ctx context.Context) error
+}
+
+// ExperimentTrace is the trace of one experiment item
+type ExperimentTrace interface {
+	ID() string
+	// Score records an evaluation as a score of the trace
+	Score(evaluation *Evaluation)
+	// End records the output of the task, or the error it returned, and ends the trace
+	End(output interface{}, err error)
+}
+
+// Experiment describes a run of a task over the items of a dataset
+type Experiment struct {
+	DatasetName    string
+	RunName        string
+	RunDescription *string
+	RunMetadata    map[string]interface{}
+	Task           Task
+	Evaluators     []Evaluator
+
+	// Concurrency is the maximum number of items processed at once.
+	// Defaults to DefaultExperimentConcurrency.
+	Concurrency int
+
+	// Tracer records the trace of each item. When nil, the traces are sent
+	// through the client's connection by a tracer created for the experiment.
+	Tracer ExperimentTracer
+}
+
+// ItemResult is the outcome of an experiment for one dataset item
+type ItemResult struct {
+	Item        Item
+	TraceID     string
+	Output      interface{}
+	Evaluations []Evaluation
+
+	// Err is the error returned by the task or an evaluator, or the error
+	// that prevented the trace from being linked to the run
+	Err error
+}
+
+// ScoreSummary aggregates the numeric values of the evaluations with the same name
+type ScoreSummary struct {
+	Count int
+	Mean  float64
+	Min   float64
+	Max   float64
+}
+
+// ExperimentResult summarizes an experiment
+type ExperimentResult struct {
+	DatasetName string
+	RunName     string
+
+	// Items holds the result of every processed item, in dataset order
+	Items     []ItemResult
+	Succeeded int
+	Failed    int
+
+	// Scores holds a summary per evaluation name. Boolean values count as 0 or 1;
+	// categorical values are not summarized.
+	Scores map[string]ScoreSummary
+}
+
+// RunExperiment runs the task over every active item of the dataset, scores each
+// output with the evaluators and links the resulting traces to the named run.
+// See Run for the details.
+func (c *Client) RunExperiment(ctx context.Context, datasetName, runName string, task Task, evaluators ...Evaluator) (*ExperimentResult, error) {
+	return c.Run(ctx, &Experiment{
+		DatasetName: datasetName,
+		RunName:     runName,
+		Task:        task,
+		Evaluators:  evaluators,
+	})
+}
+
+// Run pages through the items of the dataset and runs the experiment's task on each
+// active item, with at most Concurrency items in flight. Every execution is recorded
+// as a trace that is linked to the run through CreateRunItem, and the evaluations of
+// its output are recorded as scores on the trace.
+//
+// Failures of individual items are reported in the result rather than stopping the
+// experiment. The returned error is set if the items could not be listed, the
+// context was cancelled, or the traces could not be sent; the result is returned
+// either way. A tracer created for the experiment reports the errors of every send
+// during the run, while a tracer set on the experiment only reports the final flush.
+func (c *Client) Run(ctx context.Context, exp *Experiment) (*ExperimentResult, error) {
+	concurrency := exp.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultExperimentConcurrency
+	}
+	tracer := exp.Tracer
+	var own *batchTracer
+	if tracer == nil {
+		own = newBatchTracer(ingestion.NewClient(c.httpClient))
+		tracer = own
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results []*ItemResult
+		sem     = make(chan struct{}, concurrency)
+	)
+	it := c.IterItems(ctx, exp.DatasetName)
+	for it.Next() {
+		item := it.Item()
+		if item.Status == ItemStatusArchived {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		result := &ItemResult{Item: item}
+		results = append(results, result)
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			c.runItem(ctx, exp, tracer, result)
+		}()
+	}
+	wg.Wait()
+
+	err := it.Err()
+	if err == nil {
+		err = ctx.Err()
+	}
+	// Send the traces even if the experiment was cancelled
+	flushCtx := context.WithoutCancel(ctx)
+	if own != nil {
+		err = errors.Join(err, own.shutdown(flushCtx))
+	} else {
+		err = errors.Join(err, tracer.Flush(flushCtx))
+	}
+
+	return summarize(exp, results), err
+}
+
+// runItem runs the task and the evaluators on one item and links its trace to the run
+func (c *Client) runItem(ctx context.Context, exp *Experiment, tracer ExperimentTracer, result *ItemResult) {
+	item := result.Item
+	taskCtx, trace := tracer.StartTrace(ctx, exp.RunName, item.Input, map[string]interface{}{
+		"datasetName":   exp.DatasetName,
+		"datasetItemId": item.ID,
+		"runName":       exp.RunName,
+	})
+	result.TraceID = trace.ID()
+
+	var errs []error
+	output, err := exp.Task(taskCtx, item)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		result.Output = output
+		for _, evaluate := range exp.Evaluators {
+			evaluation, err := evaluate(ctx, item, output)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if evaluation == nil {
+				continue
+			}
+			trace.Score(evaluation)
+			result.Evaluations = append(result.Evaluations, *evaluation)
+		}
+	}
+	trace.End(output, err)
+
+	if _, err := c.CreateRunItem(ctx, &CreateRunItemRequest{
+		RunName:        exp.RunName,
+		RunDescription: exp.RunDescription,
+		RunMetadata:    exp.RunMetadata,
+		DatasetItemID:  item.ID,
+		TraceID:        trace.ID(),
+	}); err != nil {
+		errs = append(errs, err)
+	}
+	result.Err = errors.Join(errs...)
+}
+
+// ScoreRequest returns the request that records the evaluation as a score of the
+// trace. The API expects boolean scores as 0 or 1.
+func (e *Evaluation) ScoreRequest(traceID string) *scores.CreateRequest {
+	req := &scores.CreateRequest{
+		Name:     e.Name,
+		Value:    e.Value,
+		TraceID:  traceID,
+		Comment:  e.Comment,
+		DataType: e.DataType,
+	}
+	if b, ok := e.Value.(bool); ok {
+		boolean := scores.BooleanScore(traceID, e.Name, b)
+		req.Value = boolean.Value
+		if req.DataType == nil {
+			req.DataType = boolean.DataType
+		}
+	}
+	return req
+}
+
+// summarize counts the item outcomes and aggregates the numeric evaluations
+func summarize(exp *Experiment, results []*ItemResult) *ExperimentResult {
+	summary := &ExperimentResult{
+		DatasetName: exp.DatasetName,
+		RunName:     exp.RunName,
+		Items:       make([]ItemResult, 0, len(results)),
+		Scores:      map[string]ScoreSummary{},
+	}
+
+	totals := map[string]float64{}
+	for _, result := range results {
+		summary.Items = append(summary.Items, *result)
+		if result.Err != nil {
+			summary.Failed++
+		} else {
+			summary.Succeeded++
+		}
+
+		for _, evaluation := range result.Evaluations {
+			value, ok := scores.FloatValue(evaluation.Value)
+			if !ok {
+				continue
+			}
+			s, seen := summary.Scores[evaluation.Name]
+			if !seen || value < s.Min {
+				s.Min = value
+			}
+			if !seen || value > s.Max {
+				s.Max = value
+			}
+			s.Count++
+			totals[evaluation.Name] += value
+			s.Mean = totals[evaluation.Name] / float64(s.Count)
+			summary.Scores[evaluation.Name] = s
+		}
+	}
+	return summary
+}
+
+// batchTracer is the tracer of experiments without one. It sends the traces
+// through its own batcher and collects the errors of every send.
+type batchTracer struct {
+	batcher *ingestion.Batcher
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// newBatchTracer creates a batchTracer that sends events through the client
+func newBatchTracer(client *ingestion.Client) *batchTracer {
+	t := &batchTracer{}
+	t.batcher = ingestion.NewBatcher(client, ingestion.WithErrorHandler(t.report))
+	return t
+}
+
+// StartTrace records the trace-create event of an item. The context is returned as is.
+func (t *batchTracer) StartTrace(ctx context.Context, name string, input interface{}, metadata map[string]interface{}) (context.Context, ExperimentTrace) {
+	trace := &batchTrace{tracer: t, id: randomHex(16)}
+	now := time.Now()
+	t.emit(ingestion.NewTraceCreateEvent(&traces.CreateTraceRequest{
+		ID:        &trace.id,
+		Name:      &name,
+		Input:     input,
+		Metadata:  metadata,
+		Timestamp: &now,
+	}))
+	return ctx, trace
+}
+
+// Flush sends the events recorded so far
+func (t *batchTracer) Flush(ctx context.Context) error {
+	return t.batcher.Flush(ctx)
+}
+
+// shutdown sends the remaining events and returns every error reported since the
+// tracer was created
+func (t *batchTracer) shutdown(ctx context.Context) error {
+	err := t.batcher.Shutdown(ctx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return errors.Join(append([]error{err}, t.errs...)...)
+}
+
+// emit queues an event, reporting events that could not be queued
+func (t *batchTracer) emit(event ingestion.Event) {
+	t.report(t.batcher.Enqueue(event))
+}
+
+// report collects a send error
+func (t *batchTracer) report(err error) {
+	if err == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errs = append(t.errs, err)
+}
+
+// batchTrace is a trace recorded by a batchTracer
+type batchTrace struct {
+	tracer *batchTracer
+	id     string
+}
+
+// ID returns the trace ID
+func (t *batchTrace) ID() string {
+	return t.id
+}
+
+// Score records a score-create event
+func (t *batchTrace) Score(evaluation *Evaluation) {
+	t.tracer.emit(ingestion.NewScoreCreateEvent(evaluation.ScoreRequest(t.id)))
+}
+
+// End records the task error as an event, or the output on the trace
+func (t *batchTrace) End(output interface{}, err error) {
+	if err != nil {
+		now := time.Now()
+		t.tracer.emit(ingestion.NewEventCreateEvent(&observations.CreateEventRequest{
+			ID:            types.String(randomHex(8)),
+			TraceID:       &t.id,
+			Name:          types.String("task-error"),
+			StartTime:     &now,
+			Level:         types.String("ERROR"),
+			StatusMessage: types.String(err.Error()),
+		}))
+		return
+	}
+	t.tracer.emit(ingestion.NewTraceCreateEvent(&traces.CreateTraceRequest{ID: &t.id, Output: output}))
+}
+
+// randomHex returns n random bytes as a lowercase hexadecimal string
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
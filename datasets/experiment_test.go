@@ -0,0 +1,131 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestRunExperiment(t *testing.T) {
+	items := []Item{
+		{ID: "item-1", Input: "a", ExpectedOutput: "A", Status: ItemStatusActive},
+		{ID: "item-2", Input: "b", ExpectedOutput: "B", Status: ItemStatusActive},
+		{ID: "item-3", Input: "c", Status: ItemStatusArchived},
+		{ID: "item-4", Input: "fail", Status: ItemStatusActive},
+	}
+
+	type event struct {
+		Type string                 `json:"type"`
+		Body map[string]interface{} `json:"body"`
+	}
+	var (
+		mu       sync.Mutex
+		runItems []CreateRunItemRequest
+		events   []event
+		failing  atomic.Bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/api/public/datasets/qa/items":
+			json.NewEncoder(w).Encode(ItemListResponse{Data: items, Meta: types.MetaResponse{Page: 1, TotalPages: 1}})
+		case "/api/public/dataset-run-items":
+			var req CreateRunItemRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			runItems = append(runItems, req)
+			json.NewEncoder(w).Encode(RunItem{DatasetItemID: req.DatasetItemID, TraceID: req.TraceID})
+		case "/api/public/ingestion":
+			if failing.Load() {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var body struct {
+				Batch []event `json:"batch"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			events = append(events, body.Batch...)
+			w.WriteHeader(http.StatusMultiStatus)
+			w.Write([]byte(`{"successes":[],"errors":[]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	task := func(ctx context.Context, item Item) (interface{}, error) {
+		if item.Input == "fail" {
+			return nil, errors.New("task failed")
+		}
+		return item.ExpectedOutput, nil
+	}
+	exactMatch := func(ctx context.Context, item Item, output interface{}) (*Evaluation, error) {
+		return &Evaluation{Name: "exact_match", Value: output == item.ExpectedOutput}, nil
+	}
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	t.Run("sends the traces without a tracer", func(t *testing.T) {
+		result, err := client.RunExperiment(context.Background(), "qa", "run-1", task, exactMatch)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Items) != 3 || result.Items[0].Item.ID != "item-1" || result.Items[2].Item.ID != "item-4" {
+			t.Fatalf("expected the active items in dataset order, got %+v", result.Items)
+		}
+		if result.Succeeded != 2 || result.Failed != 1 {
+			t.Errorf("expected 2 succeeded and 1 failed, got %d and %d", result.Succeeded, result.Failed)
+		}
+		if summary := result.Scores["exact_match"]; summary.Count != 2 || summary.Mean != 1 {
+			t.Errorf("unexpected score summary: %+v", summary)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(runItems) != 3 {
+			t.Fatalf("expected 3 run items, got %d", len(runItems))
+		}
+		// Every linked trace must have been sent
+		traces := map[string]bool{}
+		counts := map[string]int{}
+		for _, e := range events {
+			counts[e.Type]++
+			if e.Type == "trace-create" {
+				traces[e.Body["id"].(string)] = true
+			}
+			// Boolean evaluations are sent as numeric BOOLEAN scores
+			if e.Type == "score-create" && (e.Body["value"] != 1.0 || e.Body["dataType"] != "BOOLEAN") {
+				t.Errorf("unexpected score: %v", e.Body)
+			}
+		}
+		for _, runItem := range runItems {
+			if runItem.RunName != "run-1" || !traces[runItem.TraceID] {
+				t.Errorf("expected the trace of run item %+v to be sent", runItem)
+			}
+		}
+		if counts["score-create"] != 2 || counts["event-create"] != 1 {
+			t.Errorf("expected 2 scores and 1 task error event, got %v", counts)
+		}
+	})
+
+	t.Run("reports traces that could not be sent", func(t *testing.T) {
+		failing.Store(true)
+		defer failing.Store(false)
+
+		result, err := client.RunExperiment(context.Background(), "qa", "run-2", task, exactMatch)
+		if err == nil {
+			t.Fatal("expected the ingestion failures to be reported")
+		}
+		if result == nil || len(result.Items) != 3 {
+			t.Errorf("expected the result to be returned with the error, got %+v", result)
+		}
+	})
+}
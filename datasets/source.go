@@ -0,0 +1,115 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/traces"
+)
+
+// SourceOption is a functional option for creating dataset items from traces and observations
+type SourceOption func(*sourceConfig)
+
+type sourceConfig struct {
+	outputAsExpected bool
+	expectedOutput   interface{}
+	metadataKeys     []string
+	filterMetadata   bool
+}
+
+// WithOutputAsExpectedOutput uses the output of the trace or observation as the
+// expected output of the item
+func WithOutputAsExpectedOutput() SourceOption {
+	return func(c *sourceConfig) {
+		c.outputAsExpected = true
+	}
+}
+
+// WithExpectedOutput sets the expected output of the item, for example a
+// corrected version of the recorded output
+func WithExpectedOutput(expectedOutput interface{}) SourceOption {
+	return func(c *sourceConfig) {
+		c.expectedOutput = expectedOutput
+	}
+}
+
+// WithMetadataKeys copies only the given metadata keys to the item.
+// Without keys, no metadata is copied.
+func WithMetadataKeys(keys ...string) SourceOption {
+	return func(c *sourceConfig) {
+		c.metadataKeys = keys
+		c.filterMetadata = true
+	}
+}
+
+// CreateItemFromTrace creates a dataset item from the input of a trace and links
+// the item to it. The trace metadata is copied unless filtered with WithMetadataKeys.
+func (c *Client) CreateItemFromTrace(ctx context.Context, datasetName, traceID string, opts ...SourceOption) (*Item, error) {
+	trace, err := traces.NewClient(c.httpClient).Get(ctx, traceID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := newSourceConfig(opts).itemRequest(datasetName, trace.Input, trace.Output, trace.Metadata)
+	req.SourceTraceID = &trace.ID
+	return c.CreateItem(ctx, req)
+}
+
+// CreateItemFromObservation creates a dataset item from the input of an observation
+// and links the item to it and its trace. The observation metadata is copied unless
+// filtered with WithMetadataKeys.
+func (c *Client) CreateItemFromObservation(ctx context.Context, datasetName, observationID string, opts ...SourceOption) (*Item, error) {
+	observation, err := observations.NewClient(c.httpClient).Get(ctx, observationID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := newSourceConfig(opts).itemRequest(datasetName, observation.Input, observation.Output, observation.Metadata)
+	req.SourceTraceID = observation.TraceID
+	req.SourceObservationID = &observation.ID
+	return c.CreateItem(ctx, req)
+}
+
+// newSourceConfig applies the options
+func newSourceConfig(opts []SourceOption) *sourceConfig {
+	cfg := &sourceConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// itemRequest builds the item request from the recorded input, output and metadata
+func (cfg *sourceConfig) itemRequest(datasetName string, input, output json.RawMessage, metadata map[string]interface{}) *CreateItemRequest {
+	req := &CreateItemRequest{
+		DatasetName:    &datasetName,
+		Input:          rawValue(input),
+		ExpectedOutput: cfg.expectedOutput,
+		Metadata:       metadata,
+	}
+	if req.ExpectedOutput == nil && cfg.outputAsExpected {
+		req.ExpectedOutput = rawValue(output)
+	}
+
+	if cfg.filterMetadata {
+		req.Metadata = nil
+		for _, key := range cfg.metadataKeys {
+			if value, ok := metadata[key]; ok {
+				if req.Metadata == nil {
+					req.Metadata = map[string]interface{}{}
+				}
+				req.Metadata[key] = value
+			}
+		}
+	}
+	return req
+}
+
+// rawValue returns nil for an empty value, so that it is left out of the request
+func rawValue(value json.RawMessage) interface{} {
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,58 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+)
+
+func TestCreateItemFromSource(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/public/traces/trace-1":
+			w.Write([]byte(`{"id":"trace-1","input":{"question":"2+2"},"output":"4","metadata":{"env":"prod","user":"u-1"}}`))
+		case "/api/public/observations/obs-1":
+			w.Write([]byte(`{"id":"obs-1","traceId":"trace-1","type":"GENERATION","input":"prompt","metadata":{"env":"prod","user":"u-1"}}`))
+		case "/api/public/dataset-items":
+			created = nil
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"id":"item-1"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	t.Run("trace", func(t *testing.T) {
+		if _, err := client.CreateItemFromTrace(context.Background(), "qa", "trace-1", WithOutputAsExpectedOutput()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created["input"].(map[string]interface{})["question"] != "2+2" || created["expectedOutput"] != "4" {
+			t.Errorf("expected the trace input and output, got %v", created)
+		}
+		if created["sourceTraceId"] != "trace-1" || len(created["metadata"].(map[string]interface{})) != 2 {
+			t.Errorf("expected the trace link and metadata, got %v", created)
+		}
+	})
+
+	t.Run("observation", func(t *testing.T) {
+		if _, err := client.CreateItemFromObservation(context.Background(), "qa", "obs-1", WithOutputAsExpectedOutput(), WithMetadataKeys("env")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created["input"] != "prompt" || created["sourceTraceId"] != "trace-1" || created["sourceObservationId"] != "obs-1" {
+			t.Errorf("expected the observation input and links, got %v", created)
+		}
+		if _, ok := created["expectedOutput"]; ok {
+			t.Errorf("expected no expected output for an observation without output, got %v", created["expectedOutput"])
+		}
+		if metadata := created["metadata"].(map[string]interface{}); len(metadata) != 1 || metadata["env"] != "prod" {
+			t.Errorf("expected only the env metadata key, got %v", metadata)
+		}
+	})
+}
@@ -0,0 +1,496 @@
+package datasets
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/google/uuid"
+)
+
+// Format is a file format for importing and exporting dataset items
+type Format string
+
+// Supported import and export formats
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+// ErrFormat is returned for import and export formats that are not supported
+var ErrFormat = errors.New("datasets: unsupported format")
+
+// DefaultImportConcurrency is the number of items Import creates at once
+const DefaultImportConcurrency = 4
+
+// maxLineSize is the longest JSONL line Import accepts
+const maxLineSize = 16 << 20
+
+// csvColumns are the columns written by Export in the CSV format
+var csvColumns = []string{"id", "input", "expectedOutput", "metadata", "sourceTraceId", "sourceObservationId", "status"}
+
+// ImportOption is a functional option for configuring Import
+type ImportOption func(*importConfig)
+
+type importConfig struct {
+	inputFields          []string
+	expectedOutputFields []string
+	metadataFields       []string
+	idField              string
+	requireID            bool
+	contentIDs           bool
+	concurrency          int
+	skipExisting         bool
+}
+
+// WithInputFields sets the record fields that make up the item input.
+// A single field is used as is; several fields are combined into an object
+// keyed by field name. Defaults to "input".
+func WithInputFields(fields ...string) ImportOption {
+	return func(c *importConfig) {
+		c.inputFields = fields
+	}
+}
+
+// WithExpectedOutputFields sets the record fields that make up the expected output,
+// combined as in WithInputFields. Defaults to "expectedOutput".
+func WithExpectedOutputFields(fields ...string) ImportOption {
+	return func(c *importConfig) {
+		c.expectedOutputFields = fields
+	}
+}
+
+// WithMetadataFields sets the record fields that make up the item metadata.
+// A single field holding an object is used as is; otherwise the fields are
+// combined into an object keyed by field name. Defaults to "metadata".
+func WithMetadataFields(fields ...string) ImportOption {
+	return func(c *importConfig) {
+		c.metadataFields = fields
+	}
+}
+
+// WithIDField takes the item ID from the given record field instead of "id".
+// Records without the field are reported as failures.
+func WithIDField(field string) ImportOption {
+	return func(c *importConfig) {
+		c.idField = field
+		c.requireID = true
+	}
+}
+
+// WithContentIDs ignores the ID field of the records and derives every item ID
+// from the item content, e.g. to copy an exported dataset into another one
+func WithContentIDs() ImportOption {
+	return func(c *importConfig) {
+		c.contentIDs = true
+	}
+}
+
+// WithImportConcurrency sets the number of items created at once
+func WithImportConcurrency(n int) ImportOption {
+	return func(c *importConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithSkipExisting skips records whose item ID already exists in the dataset,
+// so that an interrupted import only sends the remaining records when rerun
+func WithSkipExisting() ImportOption {
+	return func(c *importConfig) {
+		c.skipExisting = true
+	}
+}
+
+// ImportFailure describes a record that could not be imported
+type ImportFailure struct {
+	Line int
+	Err  error
+}
+
+// ImportResult summarizes an import
+type ImportResult struct {
+	Imported int
+	Skipped  int
+
+	// Failures holds the records that could not be imported, ordered by line
+	Failures []ImportFailure
+}
+
+// record is a parsed line of an import file
+type record struct {
+	line   int
+	fields map[string]interface{}
+}
+
+// Import reads records from r and upserts them as items of the dataset, with at
+// most the configured number of requests in flight. The dataset must exist.
+//
+// In the CSV format the first row holds the field names, and cells holding a
+// JSON value are decoded, so a string such as "42" must be quoted as JSON to be
+// read as a string. The ID, status and source cells are read as is. Empty cells
+// are treated as missing fields.
+//
+// Records are upserted by the ID in their "id" field, so a file written by Export
+// can be edited and imported again to update its items. The "status",
+// "sourceTraceId" and "sourceObservationId" fields are imported as well.
+// Records without an ID get one derived from the dataset name and the item
+// content: importing the same records again does not duplicate them, but an
+// edited record becomes a new item.
+//
+// Records that cannot be imported are reported in the result; the returned error
+// is set if the file could not be read or the context was cancelled.
+func (c *Client) Import(ctx context.Context, datasetName string, r io.Reader, format Format, opts ...ImportOption) (*ImportResult, error) {
+	cfg := &importConfig{
+		inputFields:          []string{"input"},
+		expectedOutputFields: []string{"expectedOutput"},
+		metadataFields:       []string{"metadata"},
+		idField:              "id",
+		concurrency:          DefaultImportConcurrency,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.concurrency <= 0 {
+		cfg.concurrency = DefaultImportConcurrency
+	}
+	if format != FormatJSONL && format != FormatCSV {
+		return nil, ErrFormat
+	}
+
+	existing := map[string]bool{}
+	if cfg.skipExisting {
+		it := c.IterItems(ctx, datasetName)
+		for it.Next() {
+			existing[it.Item().ID] = true
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		result = &ImportResult{}
+		sem    = make(chan struct{}, cfg.concurrency)
+	)
+	fail := func(line int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.Failures = append(result.Failures, ImportFailure{Line: line, Err: err})
+	}
+
+	err := readRecords(r, format, cfg.textFields(), fail, func(rec record) error {
+		req, err := cfg.itemRequest(datasetName, rec.fields)
+		if err != nil {
+			fail(rec.line, err)
+			return nil
+		}
+		if existing[*req.ID] {
+			mu.Lock()
+			result.Skipped++
+			mu.Unlock()
+			return nil
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if _, err := c.CreateItem(ctx, req); err != nil {
+				fail(rec.line, err)
+				return
+			}
+			mu.Lock()
+			result.Imported++
+			mu.Unlock()
+		}()
+		return nil
+	})
+	wg.Wait()
+
+	sort.Slice(result.Failures, func(i, j int) bool {
+		return result.Failures[i].Line < result.Failures[j].Line
+	})
+	return result, err
+}
+
+// Export writes every item of the dataset to w. The JSONL format writes one
+// Item per line; the CSV format writes a header row followed by one row per
+// item. Input, expected output and metadata are encoded as JSON unless they are
+// strings that Import reads back unchanged, so both formats round-trip. Items
+// are streamed page by page, so large datasets are not held in memory.
+func (c *Client) Export(ctx context.Context, datasetName string, w io.Writer, format Format) error {
+	it := c.IterItems(ctx, datasetName)
+
+	switch format {
+	case FormatJSONL:
+		for it.Next() {
+			data, err := sonic.ConfigStd.Marshal(it.Item())
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(append(data, '\n')); err != nil {
+				return err
+			}
+		}
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvColumns); err != nil {
+			return err
+		}
+		for it.Next() {
+			row, err := csvRow(it.Item())
+			if err != nil {
+				return err
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	default:
+		return ErrFormat
+	}
+	return it.Err()
+}
+
+// readRecords parses r and calls handle for every record. Records that cannot
+// be parsed are passed to fail; reading stops at the first error from handle.
+// CSV cells of the text fields are kept as is rather than decoded.
+func readRecords(r io.Reader, format Format, text map[string]bool, fail func(int, error), handle func(record) error) error {
+	if format == FormatCSV {
+		return readCSV(r, text, fail, handle)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := sonic.Unmarshal(data, &fields); err != nil {
+			fail(line, fmt.Errorf("invalid JSON: %w", err))
+			continue
+		}
+		if err := handle(record{line: line, fields: fields}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// readCSV parses CSV records, using the first row as field names.
+// Malformed rows are reported to fail and skipped.
+func readCSV(r io.Reader, text map[string]bool, fail func(int, error), handle func(record) error) error {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			fail(perr.StartLine, fmt.Errorf("invalid CSV: %w", err))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := cr.FieldPos(0)
+
+		fields := map[string]interface{}{}
+		for i, cell := range row {
+			if i >= len(header) || cell == "" {
+				continue
+			}
+			if text[header[i]] {
+				fields[header[i]] = cell
+			} else {
+				fields[header[i]] = csvValue(cell)
+			}
+		}
+		if err := handle(record{line: line, fields: fields}); err != nil {
+			return err
+		}
+	}
+}
+
+// textFields returns the fields that hold plain strings rather than JSON values
+func (cfg *importConfig) textFields() map[string]bool {
+	return map[string]bool{
+		cfg.idField:           true,
+		"status":              true,
+		"sourceTraceId":       true,
+		"sourceObservationId": true,
+	}
+}
+
+// itemRequest maps the fields of a record to an item upsert request
+func (cfg *importConfig) itemRequest(datasetName string, fields map[string]interface{}) (*CreateItemRequest, error) {
+	input := pick(fields, cfg.inputFields)
+	if input == nil {
+		return nil, fmt.Errorf("missing input field %s", strings.Join(cfg.inputFields, ", "))
+	}
+
+	req := &CreateItemRequest{
+		DatasetName:    &datasetName,
+		Input:          input,
+		ExpectedOutput: pick(fields, cfg.expectedOutputFields),
+	}
+	if metadata := pick(fields, cfg.metadataFields); metadata != nil {
+		m, ok := metadata.(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{cfg.metadataFields[0]: metadata}
+		}
+		req.Metadata = m
+	}
+
+	req.Status = stringField(fields, "status")
+	req.SourceTraceID = stringField(fields, "sourceTraceId")
+	req.SourceObservationID = stringField(fields, "sourceObservationId")
+
+	if !cfg.contentIDs {
+		if req.ID = stringField(fields, cfg.idField); req.ID != nil {
+			return req, nil
+		}
+		if cfg.requireID {
+			return nil, fmt.Errorf("missing ID field %s", cfg.idField)
+		}
+	}
+
+	id, err := contentID(datasetName, req)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = &id
+	return req, nil
+}
+
+// stringField returns the value of a non-empty string field, or nil
+func stringField(fields map[string]interface{}, name string) *string {
+	if value, ok := fields[name].(string); ok && value != "" {
+		return &value
+	}
+	return nil
+}
+
+// pick returns the value of a single field, or an object of the fields that are
+// present when several are given. It returns nil if none of the fields is present.
+func pick(fields map[string]interface{}, names []string) interface{} {
+	if len(names) == 1 {
+		return fields[names[0]]
+	}
+
+	values := map[string]interface{}{}
+	for _, name := range names {
+		if value, ok := fields[name]; ok {
+			values[name] = value
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// contentID derives a stable item ID from the dataset name and the item content.
+// The status and source links are left out, so that archiving an item keeps its ID.
+func contentID(datasetName string, req *CreateItemRequest) (string, error) {
+	data, err := sonic.ConfigStd.Marshal(&CreateItemRequest{
+		DatasetName:    &datasetName,
+		Input:          req.Input,
+		ExpectedOutput: req.ExpectedOutput,
+		Metadata:       req.Metadata,
+	})
+	if err != nil {
+		return "", err
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, data).String(), nil
+}
+
+// csvValue decodes cells holding a JSON value and keeps other cells as strings
+func csvValue(cell string) interface{} {
+	var value interface{}
+	if err := sonic.UnmarshalString(cell, &value); err == nil {
+		return value
+	}
+	return cell
+}
+
+// csvRow returns the CSV cells of an item in the order of csvColumns
+func csvRow(item Item) ([]string, error) {
+	values := []interface{}{item.Input, item.ExpectedOutput, item.Metadata}
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cell, err := csvCell(value)
+		if err != nil {
+			return nil, err
+		}
+		cells[i] = cell
+	}
+
+	return []string{
+		item.ID,
+		cells[0],
+		cells[1],
+		cells[2],
+		deref(item.SourceTraceID),
+		deref(item.SourceObservationID),
+		item.Status,
+	}, nil
+}
+
+// csvCell encodes a value as a CSV cell that csvValue decodes to the same value:
+// strings as is unless they would be read as JSON, other values as JSON
+func csvCell(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		if cell, ok := csvValue(v).(string); ok && cell == v && v != "" {
+			return v, nil
+		}
+	case map[string]interface{}:
+		if v == nil {
+			return "", nil
+		}
+	}
+	return sonic.ConfigStd.MarshalToString(value)
+}
+
+// deref returns the value of an optional string, or an empty string
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,208 @@
+package datasets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// itemStore is a stand-in dataset items endpoint that upserts items by ID
+type itemStore struct {
+	mu    sync.Mutex
+	items map[string]CreateItemRequest
+	order []string
+}
+
+func (s *itemStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/public/dataset-items":
+		var req CreateItemRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if _, ok := s.items[*req.ID]; !ok {
+			s.order = append(s.order, *req.ID)
+		}
+		s.items[*req.ID] = req
+		json.NewEncoder(w).Encode(Item{ID: *req.ID})
+	case r.Method == http.MethodGet:
+		response := ItemListResponse{Meta: types.MetaResponse{Page: 1, TotalPages: 1}}
+		for _, id := range s.order {
+			req := s.items[id]
+			status := ItemStatusActive
+			if req.Status != nil {
+				status = *req.Status
+			}
+			response.Data = append(response.Data, Item{
+				ID:                  id,
+				Input:               req.Input,
+				ExpectedOutput:      req.ExpectedOutput,
+				Metadata:            req.Metadata,
+				SourceTraceID:       req.SourceTraceID,
+				SourceObservationID: req.SourceObservationID,
+				Status:              status,
+			})
+		}
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+func TestImport(t *testing.T) {
+	store := &itemStore{items: map[string]CreateItemRequest{}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	t.Run("jsonl", func(t *testing.T) {
+		file := `{"input":{"question":"2+2"},"expectedOutput":"4","metadata":{"topic":"math"}}
+not json
+{"expectedOutput":"no input"}
+
+{"input":"capital of France","expectedOutput":"Paris"}
+`
+		result, err := client.Import(context.Background(), "qa", strings.NewReader(file), FormatJSONL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Imported != 2 || len(result.Failures) != 2 || result.Failures[0].Line != 2 || result.Failures[1].Line != 3 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+
+		// Rerunning the import upserts the same items, or skips them
+		client.Import(context.Background(), "qa", strings.NewReader(file), FormatJSONL)
+		if len(store.items) != 2 {
+			t.Errorf("expected the rerun to update the existing items, got %d items", len(store.items))
+		}
+		result, _ = client.Import(context.Background(), "qa", strings.NewReader(file), FormatJSONL, WithSkipExisting())
+		if result.Imported != 0 || result.Skipped != 2 {
+			t.Errorf("expected both items to be skipped, got %+v", result)
+		}
+	})
+
+	t.Run("csv with field mapping", func(t *testing.T) {
+		file := "id,question,context,answer,source\n" +
+			"q-1,What is 2+2?,,4,book\n" +
+			`q-2,Capital of France?,"{""region"":""europe""}",Paris,web` + "\n"
+		result, err := client.Import(context.Background(), "qa", strings.NewReader(file), FormatCSV,
+			WithInputFields("question", "context"),
+			WithExpectedOutputFields("answer"),
+			WithMetadataFields("source"),
+		)
+		if err != nil || result.Imported != 2 {
+			t.Fatalf("unexpected result: %+v, %v", result, err)
+		}
+
+		item := store.items["q-2"]
+		input := item.Input.(map[string]interface{})
+		if input["question"] != "Capital of France?" || input["context"].(map[string]interface{})["region"] != "europe" {
+			t.Errorf("unexpected input: %v", item.Input)
+		}
+		if item.ExpectedOutput != "Paris" || item.Metadata["source"] != "web" {
+			t.Errorf("unexpected item: %+v", item)
+		}
+		if _, ok := store.items["q-1"].Input.(map[string]interface{})["context"]; ok {
+			t.Error("expected the empty context cell to be left out")
+		}
+	})
+
+	t.Run("csv with malformed rows", func(t *testing.T) {
+		file := "id,input,expectedOutput\n" +
+			"r-1,first,1\n" +
+			"r-2,too,many,fields\n" +
+			`r-3,"bare "quote",3` + "\n" +
+			"r-4,last,4\n"
+		result, err := client.Import(context.Background(), "qa", strings.NewReader(file), FormatCSV)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Imported != 2 || len(result.Failures) != 2 || result.Failures[0].Line != 3 || result.Failures[1].Line != 4 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if _, ok := store.items["r-4"]; !ok {
+			t.Error("expected the rows after the malformed ones to be imported")
+		}
+	})
+}
+
+func TestExport(t *testing.T) {
+	store := &itemStore{items: map[string]CreateItemRequest{
+		"item-1": {Input: map[string]interface{}{"question": "2+2"}, ExpectedOutput: "4"},
+		"item-2": {Input: "3+3", SourceTraceID: types.String("trace-1"), Status: types.String(ItemStatusArchived)},
+	}, order: []string{"item-1", "item-2"}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	var buf bytes.Buffer
+	if err := client.Export(context.Background(), "qa", &buf, FormatCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "id,input,expectedOutput,metadata,sourceTraceId,sourceObservationId,status\n" +
+		`item-1,"{""question"":""2+2""}","""4""",,,,ACTIVE` + "\n" +
+		"item-2,3+3,,,trace-1,,ARCHIVED\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	// The exported file can be imported again, keeping the IDs, status and sources
+	for _, format := range []Format{FormatJSONL, FormatCSV} {
+		buf.Reset()
+		if err := client.Export(context.Background(), "qa", &buf, format); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := client.Import(context.Background(), "qa", &buf, format)
+		if err != nil || result.Imported != 2 || len(store.items) != 2 {
+			t.Errorf("unexpected %s re-import result: %+v, %v", format, result, err)
+		}
+		item := store.items["item-2"]
+		if item.Status == nil || *item.Status != ItemStatusArchived || item.SourceTraceID == nil || *item.SourceTraceID != "trace-1" {
+			t.Errorf("expected the %s re-import to keep the status and source, got %+v", format, item)
+		}
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	items := map[string]CreateItemRequest{
+		"number":  {Input: "6*7", ExpectedOutput: 42.0},
+		"bool":    {Input: "is 2 even?", ExpectedOutput: true},
+		"bracket": {Input: "[1,2]", ExpectedOutput: "null"},
+		"numeric": {Input: "42", ExpectedOutput: "true", Metadata: map[string]interface{}{"tags": []interface{}{"a"}}},
+		"object":  {Input: map[string]interface{}{"n": 1.0}, ExpectedOutput: []interface{}{1.0, "two"}},
+	}
+	source := &itemStore{items: map[string]CreateItemRequest{}}
+	for _, id := range []string{"number", "bool", "bracket", "numeric", "object"} {
+		source.items[id] = items[id]
+		source.order = append(source.order, id)
+	}
+	sourceServer := httptest.NewServer(source)
+	defer sourceServer.Close()
+	target := &itemStore{items: map[string]CreateItemRequest{}}
+	targetServer := httptest.NewServer(target)
+	defer targetServer.Close()
+
+	var buf bytes.Buffer
+	if err := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(sourceServer.URL))).Export(context.Background(), "qa", &buf, FormatCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(targetServer.URL))).Import(context.Background(), "qa", &buf, FormatCSV)
+	if err != nil || result.Imported != len(items) {
+		t.Fatalf("unexpected import result: %+v, %v", result, err)
+	}
+
+	for id, want := range items {
+		got := target.items[id]
+		if !reflect.DeepEqual(got.Input, want.Input) || !reflect.DeepEqual(got.ExpectedOutput, want.ExpectedOutput) || !reflect.DeepEqual(got.Metadata, want.Metadata) {
+			t.Errorf("item %s changed in the round trip: got %#v, %#v, %#v", id, got.Input, got.ExpectedOutput, got.Metadata)
+		}
+	}
+}
@@ -46,14 +46,23 @@ type Item struct {
 	UpdatedAt           time.Time              `json:"updatedAt"`
 }
 
-// CreateItemRequest represents the request body for creating a dataset item
+// Dataset item statuses
+const (
+	ItemStatusActive   = "ACTIVE"
+	ItemStatusArchived = "ARCHIVED"
+)
+
+// CreateItemRequest represents the request body for creating a dataset item.
+// When ID is set and an item with that ID exists, the item is updated instead.
 type CreateItemRequest struct {
+	ID                  *string                `json:"id,omitempty"`
 	DatasetName         *string                `json:"datasetName,omitempty"`
 	Input               interface{}            `json:"input"`
 	ExpectedOutput      interface{}            `json:"expectedOutput,omitempty"`
 	Metadata            map[string]interface{} `json:"metadata,omitempty"`
 	SourceTraceID       *string                `json:"sourceTraceId,omitempty"`
 	SourceObservationID *string                `json:"sourceObservationId,omitempty"`
+	Status              *string                `json:"status,omitempty"`
 }
 
 // ItemListResponse represents a paginated list of dataset items
@@ -101,6 +110,18 @@ type RunItem struct {
 	UpdatedAt      time.Time              `json:"updatedAt"`
 }
 
+// RunItemListResponse represents a paginated list of dataset run items
+type RunItemListResponse struct {
+	Data []RunItem          `json:"data"`
+	Meta types.MetaResponse `json:"meta"`
+}
+
+// RunWithItems represents a dataset run together with all of its run items
+type RunWithItems struct {
+	Run
+	Items []RunItem `json:"datasetRunItems"`
+}
+
 // CreateRunItemRequest represents the request body for creating a dataset run item
 type CreateRunItemRequest struct {
 	RunName        string                 `json:"runName"`
@@ -111,3 +132,8 @@ type CreateRunItemRequest struct {
 	ObservationID  *string                `json:"observationId,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 }
+
+// DeleteResponse represents the response of a dataset, item or run deletion
+type DeleteResponse struct {
+	Message string `json:"message"`
+}
@@ -69,20 +69,32 @@ func main() {
 		fmt.Printf("Created dataset item: %s\n", item.ID)
 	}
 
-	// Create a dataset run
+	// Run an experiment over the dataset items
 	runName := "example-run-" + fmt.Sprint(time.Now().Unix())
-	run, err := c.Datasets.CreateRun(ctx, &datasets.CreateRunRequest{
-		Name:        runName,
-		DatasetID:   dataset.ID,
-		Description: types.String("Example evaluation run"),
-		Metadata: map[string]interface{}{
-			"model": "gpt-4",
-		},
-	})
+	answers := map[string]string{
+		"What is the capital of France?": "Paris",
+		"What is 2 + 2?":                 "4",
+		"What color is the sky?":         "Blue",
+	}
+	task := func(ctx context.Context, item datasets.Item) (interface{}, error) {
+		question := item.Input.(map[string]interface{})["question"].(string)
+		return map[string]interface{}{"answer": answers[question]}, nil
+	}
+	exactMatch := func(ctx context.Context, item datasets.Item, output interface{}) (*datasets.Evaluation, error) {
+		expected := item.ExpectedOutput.(map[string]interface{})["answer"]
+		score := 0.0
+		if output.(map[string]interface{})["answer"] == expected {
+			score = 1
+		}
+		return &datasets.Evaluation{Name: "exact_match", Value: score}, nil
+	}
+
+	result, err := c.Datasets.RunExperiment(ctx, datasetName, runName, task, exactMatch)
 	if err != nil {
-		log.Fatalf("Failed to create dataset run: %v", err)
+		log.Fatalf("Failed to run experiment: %v", err)
 	}
-	fmt.Printf("Created dataset run: %s (ID: %s)\n", run.Name, run.ID)
+	fmt.Printf("Experiment %s: %d succeeded, %d failed, exact_match mean %.2f\n",
+		result.RunName, result.Succeeded, result.Failed, result.Scores["exact_match"].Mean)
 
 	// List dataset items
 	items, err := c.Datasets.ListItems(ctx, datasetName, &types.PaginationParams{
@@ -0,0 +1,79 @@
+package langfuse
+
+import (
+	"context"
+
+	"github.com/rohitkeshwani07/langfuse-go/datasets"
+)
+
+// ExperimentTracer returns a datasets.ExperimentTracer that records the traces of
+// an experiment with t. The task context carries the item's trace, so spans and
+// generations started with StartSpan and StartGeneration are attached to it.
+// It returns nil if t is nil, in which case the experiment creates its own tracer.
+//
+// Send errors during the run are passed to the tracer's error handler; the
+// experiment reports the error of the final flush.
+//
+// Example:
+//
+//	result, err := c.Datasets.Run(ctx, &datasets.Experiment{
+//		DatasetName: "qa",
+//		RunName:     "baseline",
+//		Task:        task,
+//		Tracer:      langfuse.ExperimentTracer(tracer),
+//	})
+func ExperimentTracer(t *Tracer) datasets.ExperimentTracer {
+	if t == nil {
+		return nil
+	}
+	return experimentTracer{tracer: t}
+}
+
+// experimentTracer adapts a Tracer to datasets.ExperimentTracer
+type experimentTracer struct {
+	tracer *Tracer
+}
+
+// StartTrace starts the trace of an item and returns a context that carries it
+func (e experimentTracer) StartTrace(ctx context.Context, name string, input interface{}, metadata map[string]interface{}) (context.Context, datasets.ExperimentTrace) {
+	trace := e.tracer.StartTrace(ctx, name, WithInput(input), WithMetadata(metadata))
+	return ContextWithTrace(ctx, trace), experimentTrace{trace: trace}
+}
+
+// Flush sends the traces recorded so far
+func (e experimentTracer) Flush(ctx context.Context) error {
+	return e.tracer.Flush(ctx)
+}
+
+// experimentTrace adapts a Trace to datasets.ExperimentTrace
+type experimentTrace struct {
+	trace *Trace
+}
+
+// ID returns the trace ID
+func (e experimentTrace) ID() string {
+	return e.trace.ID()
+}
+
+// Score records the evaluation as a score of the trace
+func (e experimentTrace) Score(evaluation *datasets.Evaluation) {
+	req := evaluation.ScoreRequest(e.trace.ID())
+	var opts []ScoreOption
+	if req.Comment != nil {
+		opts = append(opts, WithComment(*req.Comment))
+	}
+	if req.DataType != nil {
+		opts = append(opts, WithDataType(*req.DataType))
+	}
+	e.trace.Score(req.Name, req.Value, opts...)
+}
+
+// End records the task error as an event, or the output on the trace, and ends the trace
+func (e experimentTrace) End(output interface{}, err error) {
+	if err != nil {
+		e.trace.Event("task-error", WithLevel("ERROR"), WithStatusMessage(err.Error()))
+	} else {
+		e.trace.SetOutput(output)
+	}
+	e.trace.End()
+}
@@ -0,0 +1,97 @@
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/datasets"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestExperimentTracer(t *testing.T) {
+	if ExperimentTracer(nil) != nil {
+		t.Error("expected no experiment tracer for a nil tracer")
+	}
+
+	items := []datasets.Item{
+		{ID: "item-1", Input: "a", ExpectedOutput: "A", Status: datasets.ItemStatusActive},
+		{ID: "item-2", Input: "fail", Status: datasets.ItemStatusActive},
+	}
+	var (
+		mu       sync.Mutex
+		runItems = map[string]string{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/public/datasets/qa/items":
+			json.NewEncoder(w).Encode(datasets.ItemListResponse{Data: items, Meta: types.MetaResponse{Page: 1, TotalPages: 1}})
+		case "/api/public/dataset-run-items":
+			var req datasets.CreateRunItemRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			runItems[req.DatasetItemID] = req.TraceID
+			mu.Unlock()
+			json.NewEncoder(w).Encode(datasets.RunItem{DatasetItemID: req.DatasetItemID, TraceID: req.TraceID})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := datasets.NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	tracer, sent := newTestTracer(t)
+
+	task := func(ctx context.Context, item datasets.Item) (interface{}, error) {
+		if item.Input == "fail" {
+			return nil, errors.New("task failed")
+		}
+		_, span := StartSpan(ctx, "answer")
+		span.End()
+		return item.ExpectedOutput, nil
+	}
+	exactMatch := func(ctx context.Context, item datasets.Item, output interface{}) (*datasets.Evaluation, error) {
+		return &datasets.Evaluation{Name: "exact_match", Value: output == item.ExpectedOutput}, nil
+	}
+
+	result, err := client.Run(context.Background(), &datasets.Experiment{
+		DatasetName: "qa",
+		RunName:     "run-1",
+		Task:        task,
+		Evaluators:  []datasets.Evaluator{exactMatch},
+		Tracer:      ExperimentTracer(tracer),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Succeeded != 1 || result.Failed != 1 {
+		t.Errorf("expected 1 succeeded and 1 failed, got %d and %d", result.Succeeded, result.Failed)
+	}
+
+	counts := map[string]int{}
+	for _, event := range sent() {
+		counts[event.Type]++
+		switch event.Type {
+		case "span-create":
+			// Spans started by the task are attached to the item's trace
+			if event.Body["traceId"] != runItems["item-1"] {
+				t.Errorf("expected the span on the trace of item-1, got %v", event.Body)
+			}
+		case "score-create":
+			if event.Body["traceId"] != runItems["item-1"] || event.Body["value"] != 1.0 || event.Body["dataType"] != "BOOLEAN" {
+				t.Errorf("unexpected score: %v", event.Body)
+			}
+		case "event-create":
+			if event.Body["traceId"] != runItems["item-2"] || event.Body["level"] != "ERROR" {
+				t.Errorf("unexpected task error event: %v", event.Body)
+			}
+		}
+	}
+	if counts["span-create"] != 1 || counts["score-create"] != 1 || counts["event-create"] != 1 {
+		t.Errorf("unexpected events: %v", counts)
+	}
+}
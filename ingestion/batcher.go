@@ -0,0 +1,334 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+const (
+	// DefaultBatchSize is the default number of events sent in a single ingestion request
+	DefaultBatchSize = 15
+	// DefaultMaxBatchBytes is the default upper bound for the encoded size of a single batch
+	DefaultMaxBatchBytes = 3_500_000
+	// DefaultFlushInterval is the default interval at which pending events are flushed
+	DefaultFlushInterval = time.Second
+	// DefaultQueueSize is the default number of events that can be buffered before Enqueue fails
+	DefaultQueueSize = 10_000
+)
+
+var (
+	// ErrQueueFull is returned by Enqueue when the in-memory queue is at capacity
+	ErrQueueFull = errors.New("ingestion: queue is full")
+	// ErrBatcherClosed is returned when events are enqueued after Shutdown
+	ErrBatcherClosed = errors.New("ingestion: batcher is shut down")
+)
+
+// BatcherOption is a functional option for configuring the Batcher
+type BatcherOption func(*Batcher)
+
+// WithBatchSize sets the maximum number of events sent per request
+func WithBatchSize(size int) BatcherOption {
+	return func(b *Batcher) {
+		if size > 0 {
+			b.batchSize = size
+		}
+	}
+}
+
+// WithMaxBatchBytes sets the maximum encoded size of a single request batch
+func WithMaxBatchBytes(size int) BatcherOption {
+	return func(b *Batcher) {
+		if size > 0 {
+			b.maxBatchBytes = size
+		}
+	}
+}
+
+// WithFlushInterval sets how often pending events are flushed in the background
+func WithFlushInterval(interval time.Duration) BatcherOption {
+	return func(b *Batcher) {
+		if interval > 0 {
+			b.flushInterval = interval
+		}
+	}
+}
+
+// WithQueueSize sets the number of events that can be buffered in memory
+func WithQueueSize(size int) BatcherOption {
+	return func(b *Batcher) {
+		if size > 0 {
+			b.queueSize = size
+		}
+	}
+}
+
+// WithErrorHandler sets a callback invoked when a background flush fails.
+// The handler is called from the batcher goroutine and must not block.
+func WithErrorHandler(handler func(error)) BatcherOption {
+	return func(b *Batcher) {
+		b.onError = handler
+	}
+}
+
+// WithRetryPolicy sets how events rejected by the API are re-submitted.
+// Events that are rejected for good are passed to the policy's DeadLetter hook;
+// without one they are reported to the error handler.
+func WithRetryPolicy(policy RetryPolicy) BatcherOption {
+	return func(b *Batcher) {
+		b.retry = policy
+	}
+}
+
+// encodedEvent is a queued event in its encoded form. It is marshaled as-is
+// so events are only encoded once, when they are enqueued.
+type encodedEvent struct {
+	event Event
+	data  []byte
+}
+
+// EventID returns the envelope ID of the encoded event
+func (e encodedEvent) EventID() string {
+	return e.event.EventID()
+}
+
+// EventType returns the type of the encoded event
+func (e encodedEvent) EventType() EventType {
+	return e.event.EventType()
+}
+
+// MarshalJSON returns the encoded event
+func (e encodedEvent) MarshalJSON() ([]byte, error) {
+	return e.data, nil
+}
+
+type flushRequest struct {
+	ctx  context.Context
+	done chan error
+}
+
+// Batcher queues ingestion events in memory and sends them to the ingestion API
+// in the background. Events are flushed when the batch size or byte limit is
+// reached, on every flush interval, and on Flush or Shutdown.
+type Batcher struct {
+	client        *Client
+	batchSize     int
+	maxBatchBytes int
+	flushInterval time.Duration
+	queueSize     int
+	retry         RetryPolicy
+	onError       func(error)
+
+	events  chan encodedEvent
+	flushes chan flushRequest
+	stop    chan flushRequest
+	done    chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewBatcher creates a new Batcher and starts its background goroutine.
+// Call Shutdown to flush remaining events and stop the goroutine.
+func NewBatcher(client *Client, opts ...BatcherOption) *Batcher {
+	b := &Batcher{
+		client:        client,
+		batchSize:     DefaultBatchSize,
+		maxBatchBytes: DefaultMaxBatchBytes,
+		flushInterval: DefaultFlushInterval,
+		queueSize:     DefaultQueueSize,
+		retry:         DefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	b.events = make(chan encodedEvent, b.queueSize)
+	b.flushes = make(chan flushRequest)
+	b.stop = make(chan flushRequest, 1)
+	b.done = make(chan struct{})
+
+	go b.run()
+
+	return b
+}
+
+// Enqueue adds an event to the queue without blocking. It returns ErrQueueFull
+// when the queue is at capacity and ErrBatcherClosed after Shutdown.
+func (b *Batcher) Enqueue(event Event) error {
+	data, err := sonic.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	if len(data) > b.maxBatchBytes {
+		return fmt.Errorf("ingestion: event of %d bytes exceeds the batch limit of %d bytes", len(data), b.maxBatchBytes)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrBatcherClosed
+	}
+
+	select {
+	case b.events <- encodedEvent{event: event, data: data}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Flush sends all events enqueued before the call and waits for the requests to complete
+func (b *Batcher) Flush(ctx context.Context) error {
+	req := flushRequest{ctx: ctx, done: make(chan error, 1)}
+	select {
+	case b.flushes <- req:
+	case <-b.done:
+		return ErrBatcherClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops accepting events, flushes everything still queued and stops
+// the background goroutine. It is safe to call Shutdown more than once.
+func (b *Batcher) Shutdown(ctx context.Context) error {
+	req := flushRequest{ctx: ctx, done: make(chan error, 1)}
+
+	b.mu.Lock()
+	first := !b.closed
+	if first {
+		b.closed = true
+		b.stop <- req
+	}
+	b.mu.Unlock()
+
+	if !first {
+		select {
+		case <-b.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run is the background loop that owns the pending batch
+func (b *Batcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	var (
+		pending []encodedEvent
+		size    int
+	)
+
+	send := func(ctx context.Context) error {
+		if len(pending) == 0 {
+			return nil
+		}
+		batch := pending
+		pending, size = nil, 0
+		return b.send(ctx, batch)
+	}
+
+	// add appends an event to the batch, sending it first when the event would
+	// not fit and afterwards when the batch is full
+	add := func(ctx context.Context, ev encodedEvent) error {
+		var errs []error
+		if len(pending) > 0 && size+len(ev.data) > b.maxBatchBytes {
+			errs = append(errs, send(ctx))
+		}
+		pending = append(pending, ev)
+		size += len(ev.data)
+		if len(pending) >= b.batchSize {
+			errs = append(errs, send(ctx))
+		}
+		return errors.Join(errs...)
+	}
+
+	// drain moves every event currently buffered in the channel into the batch
+	// and sends whatever is pending
+	drain := func(ctx context.Context) error {
+		var errs []error
+		for {
+			select {
+			case ev := <-b.events:
+				errs = append(errs, add(ctx, ev))
+			default:
+				errs = append(errs, send(ctx))
+				return errors.Join(errs...)
+			}
+		}
+	}
+
+	for {
+		select {
+		case ev := <-b.events:
+			b.report(add(context.Background(), ev))
+		case <-ticker.C:
+			b.report(send(context.Background()))
+		case req := <-b.flushes:
+			req.done <- drain(req.ctx)
+		case req := <-b.stop:
+			req.done <- drain(req.ctx)
+			return
+		}
+	}
+}
+
+// send delivers a single batch to the ingestion API, re-submitting retryable failures
+func (b *Batcher) send(ctx context.Context, batch []encodedEvent) error {
+	req := &Request{Batch: make([]Event, len(batch))}
+	for i, ev := range batch {
+		req.Batch[i] = ev
+	}
+
+	policy := b.retry
+	if deadLetter := b.retry.DeadLetter; deadLetter != nil {
+		// hand the caller the event it enqueued rather than its encoded form
+		policy.DeadLetter = func(failed FailedEvent) {
+			if ev, ok := failed.Event.(encodedEvent); ok {
+				failed.Event = ev.event
+			}
+			deadLetter(failed)
+		}
+	}
+
+	resp, err := b.client.IngestWithRetry(ctx, req, policy)
+	if err != nil {
+		return fmt.Errorf("failed to ingest batch of %d events: %w", len(batch), err)
+	}
+	if len(resp.Errors) > 0 && policy.DeadLetter == nil {
+		return fmt.Errorf("ingestion: %d of %d events were rejected", len(resp.Errors), len(batch))
+	}
+	return nil
+}
+
+// report passes a background flush error to the configured error handler
+func (b *Batcher) report(err error) {
+	if err != nil && b.onError != nil {
+		b.onError(err)
+	}
+}
@@ -0,0 +1,110 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+)
+
+// recorder is a stand-in ingestion endpoint that records received batches
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]json.RawMessage
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Batch []json.RawMessage `json:"batch"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.batches = append(r.batches, body.Batch)
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write([]byte(`{"successes":[],"errors":[]}`))
+}
+
+func (r *recorder) sizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sizes := make([]int, len(r.batches))
+	for i, b := range r.batches {
+		sizes[i] = len(b)
+	}
+	return sizes
+}
+
+func newTestBatcher(t *testing.T, opts ...BatcherOption) (*Batcher, *recorder) {
+	t.Helper()
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	t.Cleanup(server.Close)
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	return NewBatcher(client, opts...), rec
+}
+
+func TestBatcher(t *testing.T) {
+	t.Run("flushes when the batch size is reached", func(t *testing.T) {
+		b, rec := newTestBatcher(t, WithBatchSize(2), WithFlushInterval(time.Hour))
+		for i := 0; i < 4; i++ {
+			if err := b.Enqueue(NewSDKLogEvent(i)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := b.Flush(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sizes := rec.sizes()
+		if len(sizes) != 2 || sizes[0] != 2 || sizes[1] != 2 {
+			t.Errorf("expected two batches of 2 events, got %v", sizes)
+		}
+	})
+
+	t.Run("splits batches by encoded size", func(t *testing.T) {
+		b, rec := newTestBatcher(t, WithMaxBatchBytes(200), WithFlushInterval(time.Hour))
+		for i := 0; i < 3; i++ {
+			if err := b.Enqueue(NewSDKLogEvent("0123456789")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := b.Flush(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if sizes := rec.sizes(); len(sizes) != 3 {
+			t.Errorf("expected one batch per event, got %v", sizes)
+		}
+	})
+
+	t.Run("shutdown flushes pending events and rejects new ones", func(t *testing.T) {
+		b, rec := newTestBatcher(t, WithFlushInterval(time.Hour))
+		if err := b.Enqueue(NewSDKLogEvent("a")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := b.Shutdown(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if sizes := rec.sizes(); len(sizes) != 1 || sizes[0] != 1 {
+			t.Errorf("expected a single batch with 1 event, got %v", sizes)
+		}
+		if err := b.Enqueue(NewSDKLogEvent("b")); !errors.Is(err, ErrBatcherClosed) {
+			t.Errorf("expected ErrBatcherClosed, got %v", err)
+		}
+		if err := b.Shutdown(context.Background()); err != nil {
+			t.Errorf("expected repeated shutdown to succeed, got %v", err)
+		}
+	})
+}
@@ -20,8 +20,14 @@ func NewClient(httpClient *core.HTTPClient) *Client {
 	}
 }
 
-// Ingest sends a batch of events to the ingestion API
+// Ingest sends a batch of events to the ingestion API.
+// When the HTTP client uses core.ExportModeOTLP, span, generation and event
+// create events are sent to the OTLP endpoint instead.
 func (c *Client) Ingest(ctx context.Context, req *Request) (*Response, error) {
+	if c.httpClient.ExportMode == core.ExportModeOTLP {
+		return c.ingestOTLP(ctx, req)
+	}
+
 	var response Response
 	if err := c.httpClient.DoRequest(ctx, http.MethodPost, "/api/public/ingestion", req, &response); err != nil {
 		return nil, err
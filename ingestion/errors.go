@@ -0,0 +1,27 @@
+package ingestion
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// IngestionError describes an event rejected by the ingestion API
+type IngestionError struct {
+	ID      string      `json:"id"`
+	Status  int         `json:"status"`
+	Message string      `json:"message,omitempty"`
+	Detail  interface{} `json:"error,omitempty"`
+}
+
+// Error implements the error interface
+func (e IngestionError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("ingestion error for event %s (status %d): %s", e.ID, e.Status, e.Message)
+	}
+	return fmt.Sprintf("ingestion error for event %s (status %d)", e.ID, e.Status)
+}
+
+// Retryable reports whether the event may succeed when submitted again
+func (e IngestionError) Retryable() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= http.StatusInternalServerError
+}
@@ -0,0 +1,189 @@
+package ingestion
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/scores"
+	"github.com/rohitkeshwani07/langfuse-go/traces"
+)
+
+// EventType is the type of an ingestion event
+type EventType string
+
+// Ingestion event types
+const (
+	EventTypeTraceCreate      EventType = "trace-create"
+	EventTypeScoreCreate      EventType = "score-create"
+	EventTypeSpanCreate       EventType = "span-create"
+	EventTypeSpanUpdate       EventType = "span-update"
+	EventTypeGenerationCreate EventType = "generation-create"
+	EventTypeGenerationUpdate EventType = "generation-update"
+	EventTypeEventCreate      EventType = "event-create"
+	EventTypeSDKLog           EventType = "sdk-log"
+)
+
+// Event is an entry of an ingestion batch
+type Event interface {
+	// EventID returns the envelope ID the API uses to report per-event results
+	EventID() string
+	// EventType returns the type of the event
+	EventType() EventType
+}
+
+// Envelope holds the fields shared by every ingestion event
+type Envelope struct {
+	ID        string                 `json:"id"`
+	Type      EventType              `json:"type"`
+	Timestamp time.Time              `json:"timestamp"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// EventID returns the envelope ID
+func (e Envelope) EventID() string {
+	return e.ID
+}
+
+// EventType returns the envelope type
+func (e Envelope) EventType() EventType {
+	return e.Type
+}
+
+// newEnvelope creates an envelope with a random ID and the current time
+func newEnvelope(eventType EventType) Envelope {
+	return Envelope{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// TraceCreateEvent creates or upserts a trace
+type TraceCreateEvent struct {
+	Envelope
+	Body *traces.CreateTraceRequest `json:"body"`
+}
+
+// NewTraceCreateEvent creates a trace-create event
+func NewTraceCreateEvent(body *traces.CreateTraceRequest) *TraceCreateEvent {
+	return &TraceCreateEvent{Envelope: newEnvelope(EventTypeTraceCreate), Body: body}
+}
+
+// ScoreCreateEvent creates a score
+type ScoreCreateEvent struct {
+	Envelope
+	Body *scores.CreateRequest `json:"body"`
+}
+
+// NewScoreCreateEvent creates a score-create event
+func NewScoreCreateEvent(body *scores.CreateRequest) *ScoreCreateEvent {
+	return &ScoreCreateEvent{Envelope: newEnvelope(EventTypeScoreCreate), Body: body}
+}
+
+// SpanCreateEvent creates a span
+type SpanCreateEvent struct {
+	Envelope
+	Body *observations.CreateSpanRequest `json:"body"`
+}
+
+// NewSpanCreateEvent creates a span-create event
+func NewSpanCreateEvent(body *observations.CreateSpanRequest) *SpanCreateEvent {
+	return &SpanCreateEvent{Envelope: newEnvelope(EventTypeSpanCreate), Body: body}
+}
+
+// UpdateSpanBody is the body of a span-update event.
+// Unlike the REST API, ingestion carries the span ID in the body.
+type UpdateSpanBody struct {
+	ID      string  `json:"id"`
+	TraceID *string `json:"traceId,omitempty"`
+	observations.UpdateSpanRequest
+}
+
+// SpanUpdateEvent updates a span
+type SpanUpdateEvent struct {
+	Envelope
+	Body *UpdateSpanBody `json:"body"`
+}
+
+// NewSpanUpdateEvent creates a span-update event for the span with the given ID
+func NewSpanUpdateEvent(spanID string, req *observations.UpdateSpanRequest) *SpanUpdateEvent {
+	body := &UpdateSpanBody{ID: spanID}
+	if req != nil {
+		body.UpdateSpanRequest = *req
+	}
+	return &SpanUpdateEvent{Envelope: newEnvelope(EventTypeSpanUpdate), Body: body}
+}
+
+// GenerationCreateEvent creates a generation
+type GenerationCreateEvent struct {
+	Envelope
+	Body *observations.CreateGenerationRequest `json:"body"`
+}
+
+// NewGenerationCreateEvent creates a generation-create event
+func NewGenerationCreateEvent(body *observations.CreateGenerationRequest) *GenerationCreateEvent {
+	return &GenerationCreateEvent{Envelope: newEnvelope(EventTypeGenerationCreate), Body: body}
+}
+
+// UpdateGenerationBody is the body of a generation-update event.
+// Unlike the REST API, ingestion carries the generation ID in the body.
+type UpdateGenerationBody struct {
+	ID      string  `json:"id"`
+	TraceID *string `json:"traceId,omitempty"`
+	observations.UpdateGenerationRequest
+}
+
+// GenerationUpdateEvent updates a generation
+type GenerationUpdateEvent struct {
+	Envelope
+	Body *UpdateGenerationBody `json:"body"`
+}
+
+// NewGenerationUpdateEvent creates a generation-update event for the generation with the given ID
+func NewGenerationUpdateEvent(generationID string, req *observations.UpdateGenerationRequest) *GenerationUpdateEvent {
+	body := &UpdateGenerationBody{ID: generationID}
+	if req != nil {
+		body.UpdateGenerationRequest = *req
+	}
+	return &GenerationUpdateEvent{Envelope: newEnvelope(EventTypeGenerationUpdate), Body: body}
+}
+
+// EventCreateEvent creates an event observation
+type EventCreateEvent struct {
+	Envelope
+	Body *observations.CreateEventRequest `json:"body"`
+}
+
+// NewEventCreateEvent creates an event-create event
+func NewEventCreateEvent(body *observations.CreateEventRequest) *EventCreateEvent {
+	return &EventCreateEvent{Envelope: newEnvelope(EventTypeEventCreate), Body: body}
+}
+
+// SDKLogBody is the body of an sdk-log event
+type SDKLogBody struct {
+	Log interface{} `json:"log"`
+}
+
+// SDKLogEvent records an SDK log message
+type SDKLogEvent struct {
+	Envelope
+	Body *SDKLogBody `json:"body"`
+}
+
+// NewSDKLogEvent creates an sdk-log event
+func NewSDKLogEvent(log interface{}) *SDKLogEvent {
+	return &SDKLogEvent{Envelope: newEnvelope(EventTypeSDKLog), Body: &SDKLogBody{Log: log}}
+}
+
+// RawEvent is an event with an untyped body, for event types without a dedicated struct
+type RawEvent struct {
+	Envelope
+	Body interface{} `json:"body"`
+}
+
+// NewRawEvent creates an event of the given type with an untyped body
+func NewRawEvent(eventType EventType, body interface{}) *RawEvent {
+	return &RawEvent{Envelope: newEnvelope(eventType), Body: body}
+}
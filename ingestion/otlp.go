@@ -0,0 +1,222 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/otlp"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// ingestOTLP sends the observation-create events of the batch to the OTLP
+// endpoint and the remaining events to the ingestion API. The results of both
+// are merged into a single response, so callers can treat them alike. A failed
+// request to either endpoint is reported as an error for each of its events.
+func (c *Client) ingestOTLP(ctx context.Context, req *Request) (*Response, error) {
+	var (
+		obs     []observations.Observation
+		spanned []Event
+		rest    []Event
+	)
+	for _, event := range req.Batch {
+		o, ok, err := toObservation(event)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			obs = append(obs, o)
+			spanned = append(spanned, event)
+		} else {
+			rest = append(rest, event)
+		}
+	}
+
+	response := &Response{}
+	if len(obs) > 0 {
+		// Spans are exported before the JSON events so that updates in the same
+		// batch apply to observations that already exist
+		err := otlp.NewClient(c.httpClient).Export(ctx, obs)
+		for _, event := range spanned {
+			if err == nil {
+				response.Successes = append(response.Successes, Success{ID: event.EventID(), Status: http.StatusCreated})
+			} else {
+				response.Errors = append(response.Errors, requestError(event, err))
+			}
+		}
+	}
+
+	if len(rest) > 0 {
+		var jsonResponse Response
+		if err := c.httpClient.DoRequest(ctx, http.MethodPost, "/api/public/ingestion", &Request{Batch: rest, Metadata: req.Metadata}, &jsonResponse); err != nil {
+			// The spans were exported already; only the JSON events need to be retried
+			for _, event := range rest {
+				response.Errors = append(response.Errors, requestError(event, err))
+			}
+			return response, nil
+		}
+		response.Successes = append(response.Successes, jsonResponse.Successes...)
+		response.Errors = append(response.Errors, jsonResponse.Errors...)
+	}
+
+	return response, nil
+}
+
+// requestError reports an event whose request failed, keeping the status of
+// API errors so that retryable failures are retried
+func requestError(event Event, err error) IngestionError {
+	status := http.StatusInternalServerError
+	var apiErr *core.APIError
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+	return IngestionError{ID: event.EventID(), Status: status, Message: err.Error()}
+}
+
+// toObservation converts span, generation and event create events to observations.
+// It reports false for every other event, and for events whose IDs would not survive
+// the conversion to OpenTelemetry IDs unchanged: the trace ID must be 32 and the
+// observation and parent IDs 16 hex characters. Otherwise the span would be hashed
+// apart from its trace, parent and later update events, which use the JSON path.
+func toObservation(event Event) (observations.Observation, bool, error) {
+	if encoded, ok := event.(encodedEvent); ok {
+		event = encoded.event
+	}
+
+	var (
+		o             observations.Observation
+		id            *string
+		input, output interface{}
+	)
+	switch e := event.(type) {
+	case *SpanCreateEvent:
+		b := e.Body
+		id, input, output = b.ID, b.Input, b.Output
+		o = observations.Observation{
+			Type:                "SPAN",
+			TraceID:             b.TraceID,
+			ParentObservationID: deref(b.ParentObservationID),
+			Name:                deref(b.Name),
+			StartTime:           startTime(b.StartTime, e.Timestamp),
+			EndTime:             b.EndTime,
+			Metadata:            b.Metadata,
+			Level:               deref(b.Level),
+			StatusMessage:       b.StatusMessage,
+			Version:             b.Version,
+		}
+	case *GenerationCreateEvent:
+		b := e.Body
+		id, input, output = b.ID, b.Input, b.Output
+		o = observations.Observation{
+			Type:                "GENERATION",
+			TraceID:             b.TraceID,
+			ParentObservationID: deref(b.ParentObservationID),
+			Name:                deref(b.Name),
+			StartTime:           startTime(b.StartTime, e.Timestamp),
+			EndTime:             b.EndTime,
+			CompletionStartTime: b.CompletionStartTime,
+			Model:               b.Model,
+			ModelParameters:     b.ModelParameters,
+			Metadata:            b.Metadata,
+			Level:               deref(b.Level),
+			StatusMessage:       b.StatusMessage,
+			Version:             b.Version,
+			PromptName:          b.PromptName,
+			PromptVersion:       b.PromptVersion,
+		}
+		if b.Usage != nil {
+			o.UsageDetails, o.CostDetails = usageMaps(b.Usage)
+		}
+	case *EventCreateEvent:
+		b := e.Body
+		id, input, output = b.ID, b.Input, b.Output
+		o = observations.Observation{
+			Type:                "EVENT",
+			TraceID:             b.TraceID,
+			ParentObservationID: deref(b.ParentObservationID),
+			Name:                deref(b.Name),
+			StartTime:           startTime(b.StartTime, e.Timestamp),
+			Metadata:            b.Metadata,
+			Level:               deref(b.Level),
+			StatusMessage:       b.StatusMessage,
+			Version:             b.Version,
+		}
+	}
+
+	// Observations without an ID cannot be addressed by later updates, so they stay on the JSON path
+	if id == nil || !otlp.IsSpanID(*id) || o.TraceID == nil || !otlp.IsTraceID(*o.TraceID) {
+		return o, false, nil
+	}
+	if o.ParentObservationID != "" && !otlp.IsSpanID(o.ParentObservationID) {
+		return o, false, nil
+	}
+	o.ID = *id
+
+	var err error
+	if o.Input, err = rawJSON(input); err != nil {
+		return o, false, err
+	}
+	if o.Output, err = rawJSON(output); err != nil {
+		return o, false, err
+	}
+	return o, true, nil
+}
+
+// deref returns the value of s, or an empty string
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// startTime returns the start time of an observation, falling back to the event timestamp
+func startTime(t *time.Time, fallback time.Time) time.Time {
+	if t != nil {
+		return *t
+	}
+	return fallback
+}
+
+// rawJSON encodes a value, returning nil for nil values
+func rawJSON(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := sonic.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode observation: %w", err)
+	}
+	return data, nil
+}
+
+// usageMaps splits usage into usage details and cost details keyed by usage type
+func usageMaps(u *types.Usage) (map[string]interface{}, map[string]interface{}) {
+	usage := map[string]interface{}{}
+	cost := map[string]interface{}{}
+	for key, values := range map[string][]*int{
+		"input":  {u.Input, u.PromptTokens},
+		"output": {u.Output, u.CompletionTokens},
+		"total":  {u.Total, u.TotalTokens},
+	} {
+		for _, v := range values {
+			if v != nil {
+				usage[key] = *v
+				break
+			}
+		}
+	}
+	for key, v := range map[string]*float64{"input": u.InputCost, "output": u.OutputCost, "total": u.TotalCost} {
+		if v != nil {
+			cost[key] = *v
+		}
+	}
+	return usage, cost
+}
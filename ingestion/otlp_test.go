@@ -0,0 +1,137 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/otlp"
+	"github.com/rohitkeshwani07/langfuse-go/traces"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestIngestOTLP(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+		batch []json.RawMessage
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == otlp.TracesPath {
+			return
+		}
+		var body struct {
+			Batch []json.RawMessage `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		batch = body.Batch
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL), core.WithExportMode(core.ExportModeOTLP)))
+	span := NewSpanCreateEvent(&observations.CreateSpanRequest{ID: types.String("0123456789abcdef"), TraceID: types.String("0123456789abcdef0123456789abcdef")})
+	trace := NewTraceCreateEvent(&traces.CreateTraceRequest{ID: types.String("0123456789abcdef0123456789abcdef")})
+
+	resp, err := client.Ingest(context.Background(), &Request{Batch: []Event{span, trace}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(paths) != 2 || paths[0] != otlp.TracesPath || paths[1] != "/api/public/ingestion" {
+		t.Errorf("expected the span to be exported via OTLP before the JSON batch, got %v", paths)
+	}
+	if len(batch) != 1 {
+		t.Errorf("expected only the trace in the JSON batch, got %d events", len(batch))
+	}
+	if len(resp.Successes) != 1 || resp.Successes[0].ID != span.ID {
+		t.Errorf("expected the exported span to be reported as a success, got %v", resp.Successes)
+	}
+}
+
+func TestIngestOTLPNonHexIDs(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+		batch []json.RawMessage
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		var body struct {
+			Batch []json.RawMessage `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		batch = body.Batch
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	defer server.Close()
+
+	const (
+		hexTrace = "0123456789abcdef0123456789abcdef"
+		hexSpan  = "0123456789abcdef"
+		uuidID   = "7f0c3b3e-2a5d-4d8e-9b1a-3c6f2e8d9a41"
+	)
+	events := []Event{
+		// UUID observation and trace IDs
+		NewSpanCreateEvent(&observations.CreateSpanRequest{ID: types.String(uuidID), TraceID: types.String(uuidID)}),
+		// Hex observation ID on a trace with a UUID
+		NewGenerationCreateEvent(&observations.CreateGenerationRequest{ID: types.String(hexSpan), TraceID: types.String(uuidID)}),
+		// Hex IDs under a parent with a non-hex ID
+		NewEventCreateEvent(&observations.CreateEventRequest{ID: types.String(hexSpan), TraceID: types.String(hexTrace), ParentObservationID: types.String("parent-1")}),
+		// Observation without a trace ID
+		NewSpanCreateEvent(&observations.CreateSpanRequest{ID: types.String(hexSpan)}),
+	}
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL), core.WithExportMode(core.ExportModeOTLP)))
+	if _, err := client.Ingest(context.Background(), &Request{Batch: events}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(paths) != 1 || paths[0] != "/api/public/ingestion" {
+		t.Errorf("expected only the JSON ingestion API to be called, got %v", paths)
+	}
+	if len(batch) != len(events) {
+		t.Errorf("expected all %d events in the JSON batch, got %d", len(events), len(batch))
+	}
+}
+
+func TestIngestOTLPJSONFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/public/ingestion" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	span := NewSpanCreateEvent(&observations.CreateSpanRequest{
+		ID:      types.String("0123456789abcdef"),
+		TraceID: types.String("0123456789abcdef0123456789abcdef"),
+	})
+	trace := NewTraceCreateEvent(&traces.CreateTraceRequest{ID: types.String("0123456789abcdef0123456789abcdef")})
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL), core.WithExportMode(core.ExportModeOTLP)))
+	resp, err := client.Ingest(context.Background(), &Request{Batch: []Event{span, trace}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Successes) != 1 || resp.Successes[0].ID != span.ID {
+		t.Errorf("expected the exported span to succeed, got %+v", resp.Successes)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].ID != trace.ID || !resp.Errors[0].Retryable() {
+		t.Errorf("expected a retryable error for the trace event, got %+v", resp.Errors)
+	}
+}
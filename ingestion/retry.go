@@ -0,0 +1,88 @@
+package ingestion
+
+import (
+	"context"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+)
+
+// RetryPolicy controls how IngestWithRetry re-submits rejected events
+type RetryPolicy struct {
+	// MaxAttempts is the total number of submissions per event, including the first
+	MaxAttempts int
+	// InitialBackoff is the delay before the first re-submission
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between re-submissions
+	MaxBackoff time.Duration
+	// DeadLetter is called for every event that is rejected with a non-retryable
+	// status or still fails after the last attempt. It may be nil.
+	DeadLetter func(FailedEvent)
+}
+
+// DefaultRetryPolicy returns the retry policy used by the Batcher
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+	}
+}
+
+// IngestWithRetry sends a batch and re-submits only the events that were rejected
+// with a retryable status (429 or 5xx), waiting with exponential backoff between
+// attempts. Events that are rejected for good are passed to policy.DeadLetter.
+// The returned response merges the successes of all attempts and the final errors.
+// If a re-submission fails altogether, the pending events are rejected with the
+// request error and the response so far is returned along with it.
+func (c *Client) IngestWithRetry(ctx context.Context, req *Request, policy RetryPolicy) (*Response, error) {
+	result := &Response{}
+	current := req
+
+	reject := func(failed FailedEvent) {
+		result.Errors = append(result.Errors, failed.Error)
+		if policy.DeadLetter != nil {
+			policy.DeadLetter(failed)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.Ingest(ctx, current)
+		if err != nil {
+			if attempt == 1 {
+				return nil, err
+			}
+			for _, event := range current.Batch {
+				reject(FailedEvent{Event: event, Error: IngestionError{ID: event.EventID(), Message: err.Error()}})
+			}
+			return result, err
+		}
+		result.Successes = append(result.Successes, resp.Successes...)
+
+		var retry []FailedEvent
+		for _, failed := range resp.Failures(current) {
+			if failed.Event != nil && failed.Error.Retryable() && attempt < policy.MaxAttempts {
+				retry = append(retry, failed)
+			} else {
+				reject(failed)
+			}
+		}
+		if len(retry) == 0 {
+			return result, nil
+		}
+
+		select {
+		case <-time.After(core.Backoff(attempt, policy.InitialBackoff, policy.MaxBackoff)):
+		case <-ctx.Done():
+			for _, failed := range retry {
+				reject(failed)
+			}
+			return result, ctx.Err()
+		}
+
+		current = &Request{Batch: make([]Event, len(retry)), Metadata: req.Metadata}
+		for i, failed := range retry {
+			current.Batch[i] = failed.Event
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+)
+
+func TestIngestWithRetry(t *testing.T) {
+	retryable := NewSDKLogEvent("rate limited once")
+	invalid := NewSDKLogEvent("invalid")
+	ok := NewSDKLogEvent("ok")
+
+	var (
+		mu       sync.Mutex
+		attempts [][]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Batch []struct {
+				ID string `json:"id"`
+			} `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		var ids []string
+		for _, e := range body.Batch {
+			ids = append(ids, e.ID)
+		}
+		attempts = append(attempts, ids)
+		first := len(attempts) == 1
+		mu.Unlock()
+
+		resp := Response{}
+		for _, id := range ids {
+			switch {
+			case id == invalid.ID:
+				resp.Errors = append(resp.Errors, IngestionError{ID: id, Status: 400, Message: "invalid body"})
+			case id == retryable.ID && first:
+				resp.Errors = append(resp.Errors, IngestionError{ID: id, Status: 429})
+			default:
+				resp.Successes = append(resp.Successes, Success{ID: id, Status: 201})
+			}
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	var deadLettered []FailedEvent
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		DeadLetter:     func(f FailedEvent) { deadLettered = append(deadLettered, f) },
+	}
+
+	resp, err := client.IngestWithRetry(context.Background(), &Request{Batch: []Event{retryable, invalid, ok}}, policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(attempts) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(attempts))
+	}
+	if len(attempts[1]) != 1 || attempts[1][0] != retryable.ID {
+		t.Errorf("expected only the retryable event to be re-submitted, got %v", attempts[1])
+	}
+	if len(resp.Successes) != 2 {
+		t.Errorf("expected 2 successes, got %d", len(resp.Successes))
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].ID != invalid.ID {
+		t.Errorf("expected the invalid event as the only error, got %v", resp.Errors)
+	}
+	if len(deadLettered) != 1 || deadLettered[0].Event != Event(invalid) {
+		t.Errorf("expected the invalid event to be dead-lettered, got %v", deadLettered)
+	}
+}
+
+func TestIngestWithRetryRequestError(t *testing.T) {
+	retryable := NewSDKLogEvent("rate limited")
+	ok := NewSDKLogEvent("ok")
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		json.NewEncoder(w).Encode(Response{
+			Successes: []Success{{ID: ok.ID, Status: 201}},
+			Errors:    []IngestionError{{ID: retryable.ID, Status: 429}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	var deadLettered []FailedEvent
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		DeadLetter:     func(f FailedEvent) { deadLettered = append(deadLettered, f) },
+	}
+
+	resp, err := client.IngestWithRetry(context.Background(), &Request{Batch: []Event{retryable, ok}}, policy)
+	if err == nil {
+		t.Fatal("expected the failed re-submission to be reported")
+	}
+	if resp == nil || len(resp.Successes) != 1 || resp.Successes[0].ID != ok.ID {
+		t.Fatalf("expected the successes of the first attempt to be kept, got %+v", resp)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].ID != retryable.ID {
+		t.Errorf("expected the pending event as the only error, got %v", resp.Errors)
+	}
+	if len(deadLettered) != 1 || deadLettered[0].Event != Event(retryable) {
+		t.Errorf("expected the pending event to be dead-lettered, got %v", deadLettered)
+	}
+}
@@ -2,17 +2,44 @@ package ingestion
 
 // Request represents a request to the ingestion API
 type Request struct {
-	Batch    []interface{}          `json:"batch"`
+	Batch    []Event                `json:"batch"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // Response represents a response from the ingestion API
 type Response struct {
-	Successes []string `json:"successes"`
-	Errors    []struct {
-		ID      string `json:"id"`
-		Status  int    `json:"status"`
-		Message string `json:"message"`
-		Error   string `json:"error"`
-	} `json:"errors"`
+	Successes []Success        `json:"successes"`
+	Errors    []IngestionError `json:"errors"`
+}
+
+// Success represents an event accepted by the ingestion API
+type Success struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+}
+
+// FailedEvent pairs a rejected event with the error reported for it
+type FailedEvent struct {
+	// Event is the submitted event, or nil if the error ID matched no event in the batch
+	Event Event
+	Error IngestionError
+}
+
+// Failures correlates the errors in the response with the events of the request
+// by envelope ID, in the order the errors were reported.
+func (r *Response) Failures(req *Request) []FailedEvent {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]Event, len(req.Batch))
+	for _, event := range req.Batch {
+		byID[event.EventID()] = event
+	}
+
+	failures := make([]FailedEvent, len(r.Errors))
+	for i, e := range r.Errors {
+		failures[i] = FailedEvent{Event: byID[e.ID], Error: e}
+	}
+	return failures
 }
@@ -68,3 +68,14 @@ func (c *Client) List(ctx context.Context, params *types.PaginationParams) (*Lis
 func (c *Client) Delete(ctx context.Context, modelID string) error {
 	return c.httpClient.DoRequest(ctx, http.MethodDelete, "/api/public/models/"+url.PathEscape(modelID), nil, nil)
 }
+
+// Iter returns an iterator over all models, fetching pages lazily
+func (c *Client) Iter(ctx context.Context, opts ...types.IteratorOption) *types.Iterator[Model] {
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Model, types.MetaResponse, error) {
+		response, err := c.List(ctx, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
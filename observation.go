@@ -0,0 +1,220 @@
+package langfuse
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// observation holds the state shared by span and generation handles
+type observation struct {
+	tracer  *Tracer
+	traceID string
+	id      string
+
+	mu    sync.Mutex
+	ended bool
+}
+
+// ID returns the observation ID
+func (o *observation) ID() string {
+	return o.id
+}
+
+// TraceID returns the ID of the trace the observation belongs to
+func (o *observation) TraceID() string {
+	return o.traceID
+}
+
+// StartSpan starts a child span
+func (o *observation) StartSpan(name string, opts ...Option) *Span {
+	return newSpan(o.tracer, o.traceID, &o.id, name, opts)
+}
+
+// StartGeneration starts a child generation
+func (o *observation) StartGeneration(name string, opts ...Option) *Generation {
+	return newGeneration(o.tracer, o.traceID, &o.id, name, opts)
+}
+
+// Event records a point-in-time child event
+func (o *observation) Event(name string, opts ...Option) {
+	recordEvent(o.tracer, o.traceID, &o.id, name, opts)
+}
+
+// Score records a score for the observation
+func (o *observation) Score(name string, value interface{}, opts ...ScoreOption) {
+	recordScore(o.tracer, o.traceID, &o.id, name, value, opts)
+}
+
+// Span is a handle to a span, a unit of work with a duration.
+// A Span is safe for concurrent use.
+type Span struct {
+	observation
+	update observations.UpdateSpanRequest
+}
+
+// newSpan creates a span and records its span-create event
+func newSpan(tracer *Tracer, traceID string, parentID *string, name string, opts []Option) *Span {
+	cfg := newConfig(opts)
+	s := &Span{observation: observation{tracer: tracer, traceID: traceID, id: newObservationID()}}
+
+	tracer.emit(ingestion.NewSpanCreateEvent(&observations.CreateSpanRequest{
+		ID:                  &s.id,
+		TraceID:             &s.traceID,
+		ParentObservationID: parentID,
+		Name:                &name,
+		StartTime:           &cfg.startTime,
+		Metadata:            cfg.metadata,
+		Input:               cfg.input,
+		Output:              cfg.output,
+		Level:               cfg.level,
+		StatusMessage:       cfg.statusMessage,
+		Version:             cfg.version,
+	}))
+
+	return s
+}
+
+// SetInput sets the input of the span
+func (s *Span) SetInput(input interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.update.Input = input
+}
+
+// SetOutput sets the output of the span
+func (s *Span) SetOutput(output interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.update.Output = output
+}
+
+// SetMetadata sets the metadata of the span
+func (s *Span) SetMetadata(metadata map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.update.Metadata = metadata
+}
+
+// SetLevel sets the level and status message of the span
+func (s *Span) SetLevel(level, statusMessage string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.update.Level = &level
+	s.update.StatusMessage = &statusMessage
+}
+
+// End sets the end time of the span to now and records the changes made since
+// it was started. Calling End more than once has no effect.
+func (s *Span) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+
+	update := s.update
+	update.EndTime = types.Time(time.Now())
+	event := ingestion.NewSpanUpdateEvent(s.id, &update)
+	event.Body.TraceID = &s.traceID
+	s.tracer.emit(event)
+}
+
+// Generation is a handle to a generation, a span that records a model call.
+// A Generation is safe for concurrent use.
+type Generation struct {
+	observation
+	update observations.UpdateGenerationRequest
+}
+
+// newGeneration creates a generation and records its generation-create event
+func newGeneration(tracer *Tracer, traceID string, parentID *string, name string, opts []Option) *Generation {
+	cfg := newConfig(opts)
+	g := &Generation{observation: observation{tracer: tracer, traceID: traceID, id: newObservationID()}}
+
+	req := &observations.CreateGenerationRequest{
+		ID:                  &g.id,
+		TraceID:             &g.traceID,
+		ParentObservationID: parentID,
+		Name:                &name,
+		StartTime:           &cfg.startTime,
+		Model:               cfg.model,
+		ModelParameters:     cfg.modelParameters,
+		Metadata:            cfg.metadata,
+		Input:               cfg.input,
+		Output:              cfg.output,
+		Level:               cfg.level,
+		StatusMessage:       cfg.statusMessage,
+		Version:             cfg.version,
+	}
+	if cfg.prompt != nil {
+		req.LinkPrompt(cfg.prompt)
+	}
+	tracer.emit(ingestion.NewGenerationCreateEvent(req))
+
+	return g
+}
+
+// SetInput sets the input of the generation
+func (g *Generation) SetInput(input interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.update.Input = input
+}
+
+// SetOutput sets the output of the generation
+func (g *Generation) SetOutput(output interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.update.Output = output
+}
+
+// SetMetadata sets the metadata of the generation
+func (g *Generation) SetMetadata(metadata map[string]interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.update.Metadata = metadata
+}
+
+// SetLevel sets the level and status message of the generation
+func (g *Generation) SetLevel(level, statusMessage string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.update.Level = &level
+	g.update.StatusMessage = &statusMessage
+}
+
+// SetUsage sets the token usage and cost of the generation
+func (g *Generation) SetUsage(usage types.Usage) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.update.Usage = &usage
+}
+
+// SetCompletionStartTime records when the model started returning the completion
+func (g *Generation) SetCompletionStartTime(t time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.update.CompletionStartTime = &t
+}
+
+// End sets the end time of the generation to now and records the changes made
+// since it was started. Calling End more than once has no effect.
+func (g *Generation) End() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.ended {
+		return
+	}
+	g.ended = true
+
+	update := g.update
+	update.EndTime = types.Time(time.Now())
+	event := ingestion.NewGenerationUpdateEvent(g.id, &update)
+	event.Body.TraceID = &g.traceID
+	g.tracer.emit(event)
+}
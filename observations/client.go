@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
 )
 
 // Client provides methods for observation operations
@@ -133,3 +134,26 @@ func (c *Client) ListIn(ctx context.Context, params *ListParams, out interface{}
 
 	return c.httpClient.DoRequest(ctx, http.MethodGet, path, nil, out)
 }
+
+// Iter returns an iterator over all observations matching params, fetching pages lazily.
+// params.Page and params.Limit set the first page and the page size.
+func (c *Client) Iter(ctx context.Context, params *ListParams, opts ...types.IteratorOption) *types.Iterator[Observation] {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	if p.Page != nil {
+		opts = append([]types.IteratorOption{types.WithStartPage(*p.Page)}, opts...)
+	}
+	if p.Limit != nil {
+		opts = append([]types.IteratorOption{types.WithPageSize(*p.Limit)}, opts...)
+	}
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Observation, types.MetaResponse, error) {
+		p.Page, p.Limit = &page, &limit
+		response, err := c.List(ctx, &p)
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
@@ -122,6 +122,20 @@ type CreateGenerationRequest struct {
 	Version             *string                `json:"version,omitempty"`
 }
 
+// PromptReference identifies the managed prompt a generation was produced from.
+// It is implemented by prompts.Chat, prompts.Text and prompts.Prompt.
+type PromptReference interface {
+	PromptName() string
+	PromptVersion() int
+}
+
+// LinkPrompt links the generation to the prompt it was produced from
+func (r *CreateGenerationRequest) LinkPrompt(prompt PromptReference) {
+	name, version := prompt.PromptName(), prompt.PromptVersion()
+	r.PromptName = &name
+	r.PromptVersion = &version
+}
+
 // UpdateGenerationRequest represents the request body for updating a generation
 type UpdateGenerationRequest struct {
 	EndTime             *time.Time             `json:"endTime,omitempty"`
@@ -0,0 +1,178 @@
+package langfuse
+
+import (
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/scores"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// Observation levels
+const (
+	LevelDebug   = "DEBUG"
+	LevelDefault = "DEFAULT"
+	LevelWarning = "WARNING"
+	LevelError   = "ERROR"
+)
+
+// Option configures a trace or observation when it is started.
+// Options that do not apply to the kind being started are ignored.
+type Option func(*config)
+
+type config struct {
+	startTime       time.Time
+	input           interface{}
+	output          interface{}
+	metadata        map[string]interface{}
+	version         *string
+	level           *string
+	statusMessage   *string
+	userID          *string
+	sessionID       *string
+	release         *string
+	tags            []string
+	public          *bool
+	model           *string
+	modelParameters map[string]interface{}
+	prompt          observations.PromptReference
+}
+
+// newConfig applies the options on top of the defaults
+func newConfig(opts []Option) *config {
+	cfg := &config{startTime: time.Now()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithStartTime sets the start time instead of the current time
+func WithStartTime(t time.Time) Option {
+	return func(c *config) {
+		c.startTime = t
+	}
+}
+
+// WithInput sets the input
+func WithInput(input interface{}) Option {
+	return func(c *config) {
+		c.input = input
+	}
+}
+
+// WithOutput sets the output
+func WithOutput(output interface{}) Option {
+	return func(c *config) {
+		c.output = output
+	}
+}
+
+// WithMetadata sets the metadata
+func WithMetadata(metadata map[string]interface{}) Option {
+	return func(c *config) {
+		c.metadata = metadata
+	}
+}
+
+// WithVersion sets the version
+func WithVersion(version string) Option {
+	return func(c *config) {
+		c.version = &version
+	}
+}
+
+// WithLevel sets the level of an observation
+func WithLevel(level string) Option {
+	return func(c *config) {
+		c.level = &level
+	}
+}
+
+// WithStatusMessage sets the status message of an observation
+func WithStatusMessage(message string) Option {
+	return func(c *config) {
+		c.statusMessage = &message
+	}
+}
+
+// WithUserID sets the user of a trace
+func WithUserID(userID string) Option {
+	return func(c *config) {
+		c.userID = &userID
+	}
+}
+
+// WithSessionID sets the session of a trace
+func WithSessionID(sessionID string) Option {
+	return func(c *config) {
+		c.sessionID = &sessionID
+	}
+}
+
+// WithRelease sets the release of a trace
+func WithRelease(release string) Option {
+	return func(c *config) {
+		c.release = &release
+	}
+}
+
+// WithTags sets the tags of a trace
+func WithTags(tags ...string) Option {
+	return func(c *config) {
+		c.tags = tags
+	}
+}
+
+// WithPublic makes a trace publicly accessible
+func WithPublic(public bool) Option {
+	return func(c *config) {
+		c.public = &public
+	}
+}
+
+// WithModel sets the model of a generation
+func WithModel(model string) Option {
+	return func(c *config) {
+		c.model = &model
+	}
+}
+
+// WithModelParameters sets the model parameters of a generation
+func WithModelParameters(params map[string]interface{}) Option {
+	return func(c *config) {
+		c.modelParameters = params
+	}
+}
+
+// WithPrompt links a generation to the managed prompt it was produced from,
+// such as a *prompts.Chat or *prompts.Text
+func WithPrompt(prompt observations.PromptReference) Option {
+	return func(c *config) {
+		c.prompt = prompt
+	}
+}
+
+// ScoreOption configures a score recorded with Score
+type ScoreOption func(*scores.CreateRequest)
+
+// WithComment sets the comment of a score
+func WithComment(comment string) ScoreOption {
+	return func(r *scores.CreateRequest) {
+		r.Comment = &comment
+	}
+}
+
+// WithDataType sets the data type of a score
+func WithDataType(dataType types.ScoreDataType) ScoreOption {
+	return func(r *scores.CreateRequest) {
+		r.DataType = &dataType
+	}
+}
+
+// WithConfigID links a score to a score config
+func WithConfigID(configID string) ScoreOption {
+	return func(r *scores.CreateRequest) {
+		r.ConfigID = &configID
+	}
+}
@@ -0,0 +1,92 @@
+// Package otelexporter provides an OpenTelemetry span exporter that sends spans to Langfuse.
+//
+// Spans are mapped to Langfuse traces, spans and generations and shipped
+// through the ingestion API. Spans that carry gen_ai.* semantic convention
+// attributes become generations with model, usage and cost information.
+//
+// Example:
+//
+//	exporter := otelexporter.New(c.Ingestion)
+//	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+//	defer provider.Shutdown(context.Background())
+//	otel.SetTracerProvider(provider)
+package otelexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+)
+
+// ErrShutdown is returned by ExportSpans after the exporter has been shut down
+var ErrShutdown = errors.New("otelexporter: exporter is shut down")
+
+var _ sdktrace.SpanExporter = (*Exporter)(nil)
+
+// Exporter is an sdktrace.SpanExporter that writes spans to Langfuse
+type Exporter struct {
+	batcher *ingestion.Batcher
+
+	mu      sync.Mutex
+	stopped bool
+}
+
+// Option is a functional option for configuring the Exporter
+type Option func(*exporterConfig)
+
+type exporterConfig struct {
+	batcherOpts []ingestion.BatcherOption
+}
+
+// WithBatcherOptions configures the batcher that sends the exported events,
+// e.g. its batch size, byte limit and retry policy
+func WithBatcherOptions(opts ...ingestion.BatcherOption) Option {
+	return func(c *exporterConfig) {
+		c.batcherOpts = append(c.batcherOpts, opts...)
+	}
+}
+
+// New creates a new Exporter that ships spans through the given ingestion client
+func New(client *ingestion.Client, opts ...Option) *Exporter {
+	cfg := &exporterConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Exporter{
+		batcher: ingestion.NewBatcher(client, cfg.batcherOpts...),
+	}
+}
+
+// ExportSpans converts the spans to ingestion events and sends them,
+// returning once every event has been delivered or rejected.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopped {
+		return ErrShutdown
+	}
+
+	for _, span := range spans {
+		for _, event := range spanEvents(span) {
+			if err := e.batcher.Enqueue(event); err != nil {
+				return fmt.Errorf("failed to export span %s: %w", span.SpanContext().SpanID(), err)
+			}
+		}
+	}
+
+	return e.batcher.Flush(ctx)
+}
+
+// Shutdown sends any remaining events and stops the exporter
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stopped = true
+	return e.batcher.Shutdown(ctx)
+}
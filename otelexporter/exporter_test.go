@@ -0,0 +1,130 @@
+package otelexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+)
+
+type sentEvent struct {
+	Type string                 `json:"type"`
+	Body map[string]interface{} `json:"body"`
+}
+
+// newTestProvider returns a tracer provider exporting synchronously to a stand-in
+// ingestion endpoint, and a function returning the events received so far
+func newTestProvider(t *testing.T) (*sdktrace.TracerProvider, *Exporter, func() []sentEvent) {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		events []sentEvent
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/public/ingestion" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Batch []sentEvent `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		events = append(events, body.Batch...)
+		mu.Unlock()
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	exporter := New(ingestion.NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL))))
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	return provider, exporter, func() []sentEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]sentEvent(nil), events...)
+	}
+}
+
+// byType groups events by their type
+func byType(events []sentEvent) map[string][]sentEvent {
+	grouped := map[string][]sentEvent{}
+	for _, e := range events {
+		grouped[e.Type] = append(grouped[e.Type], e)
+	}
+	return grouped
+}
+
+func TestExporter(t *testing.T) {
+	t.Run("maps spans and generations", func(t *testing.T) {
+		provider, _, sent := newTestProvider(t)
+		tracer := provider.Tracer("test")
+
+		ctx, root := tracer.Start(context.Background(), "handle-request")
+		root.SetAttributes(attribute.String(AttrUserID, "user-1"))
+		_, gen := tracer.Start(ctx, "chat gpt-4o")
+		gen.SetAttributes(
+			attribute.String(AttrGenAISystem, "openai"),
+			attribute.String(AttrGenAIRequestModel, "gpt-4o"),
+			attribute.Float64("gen_ai.request.temperature", 0.2),
+			attribute.Int(AttrGenAIUsageInputTokens, 12),
+			attribute.Int(AttrGenAIUsageOutputTokens, 30),
+			attribute.Float64(AttrGenAIUsageCost, 0.0042),
+			attribute.String(AttrGenAIPrompt, `[{"role":"user","content":"hi"}]`),
+		)
+		gen.SetStatus(codes.Error, "rate limited")
+		gen.End()
+		root.End()
+
+		events := byType(sent())
+		if len(events["trace-create"]) != 1 || len(events["span-create"]) != 1 || len(events["generation-create"]) != 1 {
+			t.Fatalf("unexpected events: %v", events)
+		}
+
+		trace := events["trace-create"][0].Body
+		traceID := root.SpanContext().TraceID().String()
+		if trace["id"] != traceID || trace["name"] != "handle-request" || trace["userId"] != "user-1" {
+			t.Errorf("unexpected trace: %v", trace)
+		}
+
+		g := events["generation-create"][0].Body
+		if g["traceId"] != traceID || g["parentObservationId"] != root.SpanContext().SpanID().String() {
+			t.Errorf("generation is not attached to the root span: %v", g)
+		}
+		if g["model"] != "gpt-4o" || g["level"] != "ERROR" || g["statusMessage"] != "rate limited" {
+			t.Errorf("unexpected generation fields: %v", g)
+		}
+		usage, _ := g["usage"].(map[string]interface{})
+		if usage["input"] != 12.0 || usage["output"] != 30.0 || usage["totalCost"] != 0.0042 {
+			t.Errorf("unexpected usage: %v", usage)
+		}
+		if params, _ := g["modelParameters"].(map[string]interface{}); params["temperature"] != 0.2 {
+			t.Errorf("unexpected model parameters: %v", g["modelParameters"])
+		}
+		if input, _ := g["input"].([]interface{}); len(input) != 1 {
+			t.Errorf("expected the prompt to be decoded as JSON, got %v", g["input"])
+		}
+	})
+
+	t.Run("rejects spans after shutdown", func(t *testing.T) {
+		_, exporter, _ := newTestProvider(t)
+		if err := exporter.Shutdown(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := exporter.ExportSpans(context.Background(), nil); !errors.Is(err, ErrShutdown) {
+			t.Errorf("expected ErrShutdown, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,262 @@
+package otelexporter
+
+import (
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/traces"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// Attribute keys read by the exporter. Keys that are not listed here are
+// copied into the observation metadata.
+const (
+	AttrGenAISystem                = "gen_ai.system"
+	AttrGenAIRequestModel          = "gen_ai.request.model"
+	AttrGenAIResponseModel         = "gen_ai.response.model"
+	AttrGenAIUsageInputTokens      = "gen_ai.usage.input_tokens"
+	AttrGenAIUsageOutputTokens     = "gen_ai.usage.output_tokens"
+	AttrGenAIUsagePromptTokens     = "gen_ai.usage.prompt_tokens"
+	AttrGenAIUsageCompletionTokens = "gen_ai.usage.completion_tokens"
+	AttrGenAIUsageTotalTokens      = "gen_ai.usage.total_tokens"
+	AttrGenAIUsageCost             = "gen_ai.usage.cost"
+	AttrGenAIPrompt                = "gen_ai.prompt"
+	AttrGenAICompletion            = "gen_ai.completion"
+
+	AttrObservationInput  = "langfuse.observation.input"
+	AttrObservationOutput = "langfuse.observation.output"
+	AttrTraceName         = "langfuse.trace.name"
+	AttrTraceTags         = "langfuse.trace.tags"
+	AttrUserID            = "langfuse.user.id"
+	AttrSessionID         = "langfuse.session.id"
+	AttrRelease           = "langfuse.release"
+	AttrVersion           = "langfuse.version"
+)
+
+// genAIRequestPrefix is the prefix of model parameter attributes such as gen_ai.request.temperature
+const genAIRequestPrefix = "gen_ai.request."
+
+// spanEvents converts an OpenTelemetry span to ingestion events: a trace-create
+// event for root spans, a span-create or generation-create event for the span
+// itself and an event-create event for each span event.
+func spanEvents(span sdktrace.ReadOnlySpan) []ingestion.Event {
+	attrs := make(map[string]attribute.Value, len(span.Attributes()))
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value
+	}
+	consumed := map[string]bool{}
+	str := func(key string) *string {
+		v, ok := attrs[key]
+		if !ok {
+			return nil
+		}
+		consumed[key] = true
+		s := v.Emit()
+		return &s
+	}
+
+	traceID := span.SpanContext().TraceID().String()
+	spanID := span.SpanContext().SpanID().String()
+	name := span.Name()
+	start := span.StartTime()
+	end := span.EndTime()
+
+	var parentID *string
+	if parent := span.Parent(); parent.IsValid() {
+		parentID = types.String(parent.SpanID().String())
+	}
+
+	var level, statusMessage *string
+	if status := span.Status(); status.Code == codes.Error {
+		level = types.String("ERROR")
+		statusMessage = types.String(status.Description)
+	}
+
+	input := decodeJSON(firstOf(str(AttrObservationInput), str(AttrGenAIPrompt)))
+	output := decodeJSON(firstOf(str(AttrObservationOutput), str(AttrGenAICompletion)))
+	version := str(AttrVersion)
+
+	var events []ingestion.Event
+
+	userID, sessionID, release := str(AttrUserID), str(AttrSessionID), str(AttrRelease)
+	traceName := str(AttrTraceName)
+	var tags []string
+	if v, ok := attrs[AttrTraceTags]; ok {
+		consumed[AttrTraceTags] = true
+		tags = v.AsStringSlice()
+	}
+
+	// Root spans create the trace; other spans only update it when they carry trace attributes
+	isRoot := !span.Parent().IsValid() || span.Parent().IsRemote()
+	if isRoot || userID != nil || sessionID != nil || release != nil || traceName != nil || tags != nil {
+		trace := &traces.CreateTraceRequest{
+			ID:        &traceID,
+			Name:      traceName,
+			UserID:    userID,
+			SessionID: sessionID,
+			Release:   release,
+			Tags:      tags,
+		}
+		if isRoot {
+			if trace.Name == nil {
+				trace.Name = &name
+			}
+			trace.Input = input
+			trace.Output = output
+			trace.Timestamp = &start
+		}
+		events = append(events, ingestion.NewTraceCreateEvent(trace))
+	}
+
+	model := firstOf(str(AttrGenAIResponseModel), str(AttrGenAIRequestModel))
+	_, isGenAI := attrs[AttrGenAISystem]
+	if model != nil || isGenAI {
+		usage := &types.Usage{
+			Input:     firstOf(intAttr(attrs, consumed, AttrGenAIUsageInputTokens), intAttr(attrs, consumed, AttrGenAIUsagePromptTokens)),
+			Output:    firstOf(intAttr(attrs, consumed, AttrGenAIUsageOutputTokens), intAttr(attrs, consumed, AttrGenAIUsageCompletionTokens)),
+			Total:     intAttr(attrs, consumed, AttrGenAIUsageTotalTokens),
+			TotalCost: floatAttr(attrs, consumed, AttrGenAIUsageCost),
+		}
+		if usage.Input == nil && usage.Output == nil && usage.Total == nil && usage.TotalCost == nil {
+			usage = nil
+		}
+
+		params := map[string]interface{}{}
+		for key, value := range attrs {
+			if strings.HasPrefix(key, genAIRequestPrefix) && !consumed[key] {
+				params[strings.TrimPrefix(key, genAIRequestPrefix)] = value.AsInterface()
+				consumed[key] = true
+			}
+		}
+		if len(params) == 0 {
+			params = nil
+		}
+
+		events = append(events, ingestion.NewGenerationCreateEvent(&observations.CreateGenerationRequest{
+			ID:                  &spanID,
+			TraceID:             &traceID,
+			ParentObservationID: parentID,
+			Name:                &name,
+			StartTime:           &start,
+			EndTime:             &end,
+			Model:               model,
+			ModelParameters:     params,
+			Metadata:            metadata(attrs, consumed),
+			Input:               input,
+			Output:              output,
+			Usage:               usage,
+			Level:               level,
+			StatusMessage:       statusMessage,
+			Version:             version,
+		}))
+	} else {
+		events = append(events, ingestion.NewSpanCreateEvent(&observations.CreateSpanRequest{
+			ID:                  &spanID,
+			TraceID:             &traceID,
+			ParentObservationID: parentID,
+			Name:                &name,
+			StartTime:           &start,
+			EndTime:             &end,
+			Metadata:            metadata(attrs, consumed),
+			Input:               input,
+			Output:              output,
+			Level:               level,
+			StatusMessage:       statusMessage,
+			Version:             version,
+		}))
+	}
+
+	for _, ev := range span.Events() {
+		evMetadata := make(map[string]interface{}, len(ev.Attributes))
+		for _, kv := range ev.Attributes {
+			evMetadata[string(kv.Key)] = kv.Value.AsInterface()
+		}
+		evName, evTime := ev.Name, ev.Time
+		events = append(events, ingestion.NewEventCreateEvent(&observations.CreateEventRequest{
+			TraceID:             &traceID,
+			ParentObservationID: &spanID,
+			Name:                &evName,
+			StartTime:           &evTime,
+			Metadata:            evMetadata,
+		}))
+	}
+
+	return events
+}
+
+// metadata returns the attributes that were not mapped to a dedicated field
+func metadata(attrs map[string]attribute.Value, consumed map[string]bool) map[string]interface{} {
+	md := map[string]interface{}{}
+	for key, value := range attrs {
+		if !consumed[key] {
+			md[key] = value.AsInterface()
+		}
+	}
+	if len(md) == 0 {
+		return nil
+	}
+	return md
+}
+
+// intAttr reads an integer attribute
+func intAttr(attrs map[string]attribute.Value, consumed map[string]bool, key string) *int {
+	v, ok := attrs[key]
+	if !ok {
+		return nil
+	}
+	consumed[key] = true
+	switch v.Type() {
+	case attribute.INT64:
+		return types.Int(int(v.AsInt64()))
+	case attribute.FLOAT64:
+		return types.Int(int(v.AsFloat64()))
+	}
+	return nil
+}
+
+// floatAttr reads a numeric attribute
+func floatAttr(attrs map[string]attribute.Value, consumed map[string]bool, key string) *float64 {
+	v, ok := attrs[key]
+	if !ok {
+		return nil
+	}
+	consumed[key] = true
+	switch v.Type() {
+	case attribute.FLOAT64:
+		return types.Float64(v.AsFloat64())
+	case attribute.INT64:
+		return types.Float64(float64(v.AsInt64()))
+	}
+	return nil
+}
+
+// firstOf returns the first non-nil value
+func firstOf[T any](values ...*T) *T {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// decodeJSON returns the decoded value if s holds a JSON object or array, and s itself otherwise
+func decodeJSON(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var v interface{}
+		if err := sonic.UnmarshalString(trimmed, &v); err == nil {
+			return v
+		}
+	}
+	return *s
+}
@@ -0,0 +1,63 @@
+// Package otlp provides a client that sends observations to the Langfuse
+// OpenTelemetry endpoint as OTLP/HTTP protobuf.
+//
+// It is used by the ingestion client when the HTTP client is created with
+// core.WithExportMode(core.ExportModeOTLP), and can be used directly:
+//
+//	err := otlp.NewClient(httpClient).Export(ctx, []observations.Observation{...})
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+)
+
+const (
+	// TracesPath is the path of the Langfuse OTLP/HTTP traces endpoint
+	TracesPath = "/api/public/otel/v1/traces"
+	// ContentType is the content type of OTLP/HTTP protobuf requests
+	ContentType = "application/x-protobuf"
+)
+
+// Client provides methods for OTLP export
+type Client struct {
+	httpClient *core.HTTPClient
+}
+
+// NewClient creates a new OTLP client
+func NewClient(httpClient *core.HTTPClient) *Client {
+	return &Client{
+		httpClient: httpClient,
+	}
+}
+
+// Export encodes the observations as OTLP spans and sends them in a single request.
+// Spans rejected by the server are reported as an error.
+func (c *Client) Export(ctx context.Context, obs []observations.Observation) error {
+	data, err := Encode(obs)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.httpClient.DoRawRequest(ctx, http.MethodPost, TracesPath, ContentType, data)
+	if err != nil {
+		return err
+	}
+
+	// The response is an ExportTraceServiceResponse; an empty or undecodable
+	// body means every span was accepted
+	var resp coltracepb.ExportTraceServiceResponse
+	if len(body) > 0 && proto.Unmarshal(body, &resp) == nil {
+		if partial := resp.GetPartialSuccess(); partial.GetRejectedSpans() > 0 {
+			return fmt.Errorf("otlp: %d spans rejected: %s", partial.GetRejectedSpans(), partial.GetErrorMessage())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestExport(t *testing.T) {
+	var got coltracepb.ExportTraceServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if r.URL.Path != TracesPath || r.Header.Get("Content-Type") != ContentType || user != "pk" || pass != "sk" {
+			t.Errorf("unexpected request: %s %v", r.URL.Path, r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := proto.Unmarshal(body, &got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", ContentType)
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Second)
+	obs := observations.Observation{
+		ID:                  "0123456789abcdef",
+		TraceID:             types.String("0123456789abcdef0123456789abcdef"),
+		ParentObservationID: "fedcba9876543210",
+		Type:                "GENERATION",
+		Name:                "completion",
+		StartTime:           start,
+		EndTime:             &end,
+		Model:               types.String("gpt-4o"),
+		Level:               "ERROR",
+		StatusMessage:       types.String("rate limited"),
+		UsageDetails:        map[string]interface{}{"input": 12, "output": 30},
+		Input:               json.RawMessage(`{"prompt":"hi"}`),
+	}
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	if err := client.Export(context.Background(), []observations.Observation{obs}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := got.GetResourceSpans()[0].GetScopeSpans()[0].GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if string(span.TraceId) != string(TraceID(*obs.TraceID)) || string(span.SpanId) != string(SpanID(obs.ID)) || string(span.ParentSpanId) != string(SpanID(obs.ParentObservationID)) {
+		t.Errorf("unexpected span IDs: %x %x %x", span.TraceId, span.SpanId, span.ParentSpanId)
+	}
+	if span.EndTimeUnixNano != uint64(end.UnixNano()) || span.Status.GetCode() != tracepb.Status_STATUS_CODE_ERROR {
+		t.Errorf("unexpected span: %v", span)
+	}
+
+	attrs := map[string]interface{}{}
+	for _, kv := range span.Attributes {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			attrs[kv.Key] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			attrs[kv.Key] = v.IntValue
+		}
+	}
+	want := map[string]interface{}{
+		AttrObservationType:          "generation",
+		AttrObservationModel:         "gpt-4o",
+		AttrObservationLevel:         "ERROR",
+		AttrObservationStatusMessage: "rate limited",
+		AttrObservationUsageDetails:  `{"input":12,"output":30}`,
+		AttrObservationInput:         `{"prompt":"hi"}`,
+		AttrGenAIUsageInputTokens:    int64(12),
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, attrs[key])
+		}
+	}
+}
+
+func TestIDs(t *testing.T) {
+	if got := SpanID("0123456789abcdef"); len(got) != 8 || got[0] != 0x01 {
+		t.Errorf("expected hex span IDs to be decoded, got %x", got)
+	}
+	if a, b := TraceID("my-trace"), TraceID("my-trace"); len(a) != 16 || string(a) != string(b) {
+		t.Errorf("expected other trace IDs to be hashed deterministically, got %x and %x", a, b)
+	}
+}
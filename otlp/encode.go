@@ -0,0 +1,281 @@
+package otlp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+)
+
+// Span attribute keys understood by the Langfuse OpenTelemetry endpoint
+const (
+	AttrObservationType                = "langfuse.observation.type"
+	AttrObservationLevel               = "langfuse.observation.level"
+	AttrObservationStatusMessage       = "langfuse.observation.status_message"
+	AttrObservationModel               = "langfuse.observation.model.name"
+	AttrObservationModelParameters     = "langfuse.observation.model.parameters"
+	AttrObservationUsageDetails        = "langfuse.observation.usage_details"
+	AttrObservationCostDetails         = "langfuse.observation.cost_details"
+	AttrObservationCompletionStartTime = "langfuse.observation.completion_start_time"
+	AttrObservationInput               = "langfuse.observation.input"
+	AttrObservationOutput              = "langfuse.observation.output"
+	AttrObservationPromptName          = "langfuse.observation.prompt.name"
+	AttrObservationPromptVersion       = "langfuse.observation.prompt.version"
+	AttrObservationMetadataPrefix      = "langfuse.observation.metadata."
+	AttrVersion                        = "langfuse.version"
+	AttrEnvironment                    = "langfuse.environment"
+
+	AttrGenAIRequestModel      = "gen_ai.request.model"
+	AttrGenAIUsageInputTokens  = "gen_ai.usage.input_tokens"
+	AttrGenAIUsageOutputTokens = "gen_ai.usage.output_tokens"
+)
+
+// scopeName identifies the spans produced by this package
+const scopeName = "github.com/rohitkeshwani07/langfuse-go"
+
+// Encode encodes the observations as an OTLP ExportTraceServiceRequest in protobuf form
+func Encode(obs []observations.Observation) ([]byte, error) {
+	spans := make([]*tracepb.Span, 0, len(obs))
+	for i := range obs {
+		span, err := toSpan(&obs[i])
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, span)
+	}
+
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			Resource: &resourcepb.Resource{
+				Attributes: []*commonpb.KeyValue{stringAttr("service.name", "langfuse-go")},
+			},
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Scope: &commonpb.InstrumentationScope{Name: scopeName},
+				Spans: spans,
+			}},
+		}},
+	}
+
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OTLP request: %w", err)
+	}
+	return data, nil
+}
+
+// toSpan converts an observation to an OTLP span. Observations without a trace
+// ID become the root of a trace derived from their own ID.
+func toSpan(o *observations.Observation) (*tracepb.Span, error) {
+	traceID := o.ID
+	if o.TraceID != nil {
+		traceID = *o.TraceID
+	}
+
+	span := &tracepb.Span{
+		TraceId:           TraceID(traceID),
+		SpanId:            SpanID(o.ID),
+		Name:              o.Name,
+		Kind:              tracepb.Span_SPAN_KIND_INTERNAL,
+		StartTimeUnixNano: uint64(o.StartTime.UnixNano()),
+		EndTimeUnixNano:   uint64(o.StartTime.UnixNano()),
+	}
+	if o.EndTime != nil {
+		span.EndTimeUnixNano = uint64(o.EndTime.UnixNano())
+	}
+	if o.ParentObservationID != "" {
+		span.ParentSpanId = SpanID(o.ParentObservationID)
+	}
+
+	attrs := []*commonpb.KeyValue{}
+	addString := func(key string, value *string) {
+		if value != nil && *value != "" {
+			attrs = append(attrs, stringAttr(key, *value))
+		}
+	}
+	addJSON := func(key string, value interface{}) error {
+		data, err := sonic.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s of observation %s: %w", key, o.ID, err)
+		}
+		attrs = append(attrs, stringAttr(key, string(data)))
+		return nil
+	}
+
+	if o.Type != "" {
+		attrs = append(attrs, stringAttr(AttrObservationType, strings.ToLower(o.Type)))
+	}
+	addString(AttrObservationModel, o.Model)
+	addString(AttrGenAIRequestModel, o.Model)
+	addString(AttrObservationStatusMessage, o.StatusMessage)
+	addString(AttrVersion, o.Version)
+	addString(AttrEnvironment, &o.Environment)
+	addString(AttrObservationPromptName, o.PromptName)
+	if o.PromptVersion != nil {
+		attrs = append(attrs, intAttr(AttrObservationPromptVersion, int64(*o.PromptVersion)))
+	}
+	if o.Level != "" {
+		attrs = append(attrs, stringAttr(AttrObservationLevel, o.Level))
+		if o.Level == "ERROR" {
+			span.Status = &tracepb.Status{Code: tracepb.Status_STATUS_CODE_ERROR}
+			if o.StatusMessage != nil {
+				span.Status.Message = *o.StatusMessage
+			}
+		}
+	}
+	if o.CompletionStartTime != nil {
+		attrs = append(attrs, stringAttr(AttrObservationCompletionStartTime, o.CompletionStartTime.UTC().Format(time.RFC3339Nano)))
+	}
+	if len(o.Input) > 0 {
+		attrs = append(attrs, stringAttr(AttrObservationInput, string(o.Input)))
+	}
+	if len(o.Output) > 0 {
+		attrs = append(attrs, stringAttr(AttrObservationOutput, string(o.Output)))
+	}
+
+	if len(o.ModelParameters) > 0 {
+		if err := addJSON(AttrObservationModelParameters, o.ModelParameters); err != nil {
+			return nil, err
+		}
+	}
+	usage := usageDetails(o)
+	if len(usage) > 0 {
+		if err := addJSON(AttrObservationUsageDetails, usage); err != nil {
+			return nil, err
+		}
+		if v, ok := toInt(usage["input"]); ok {
+			attrs = append(attrs, intAttr(AttrGenAIUsageInputTokens, v))
+		}
+		if v, ok := toInt(usage["output"]); ok {
+			attrs = append(attrs, intAttr(AttrGenAIUsageOutputTokens, v))
+		}
+	}
+	if len(o.CostDetails) > 0 {
+		if err := addJSON(AttrObservationCostDetails, o.CostDetails); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(o.Metadata))
+	for key := range o.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if s, ok := o.Metadata[key].(string); ok {
+			attrs = append(attrs, stringAttr(AttrObservationMetadataPrefix+key, s))
+		} else if err := addJSON(AttrObservationMetadataPrefix+key, o.Metadata[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	span.Attributes = attrs
+	return span, nil
+}
+
+// usageDetails returns the usage of the observation keyed by usage type,
+// preferring UsageDetails over the legacy Usage and token count fields
+func usageDetails(o *observations.Observation) map[string]interface{} {
+	if len(o.UsageDetails) > 0 {
+		return o.UsageDetails
+	}
+
+	usage := map[string]interface{}{}
+	for key, value := range o.Usage {
+		switch key {
+		case "promptTokens":
+			key = "input"
+		case "completionTokens":
+			key = "output"
+		case "totalTokens":
+			key = "total"
+		case "unit", "inputCost", "outputCost", "totalCost":
+			continue
+		}
+		if _, ok := usage[key]; !ok {
+			usage[key] = value
+		}
+	}
+	for key, value := range map[string]*int{"input": o.PromptTokens, "output": o.CompletionTokens, "total": o.TotalTokens} {
+		if _, ok := usage[key]; !ok && value != nil {
+			usage[key] = *value
+		}
+	}
+	return usage
+}
+
+// TraceID converts a Langfuse trace ID to a 16 byte OTLP trace ID. IDs made of
+// 32 hex characters, such as those created by CreateTraceID, are decoded so the
+// trace keeps its ID; other IDs are hashed.
+func TraceID(id string) []byte {
+	return otelID(id, 16)
+}
+
+// SpanID converts a Langfuse observation ID to an 8 byte OTLP span ID. IDs made of
+// 16 hex characters are decoded so the observation keeps its ID; other IDs are hashed.
+func SpanID(id string) []byte {
+	return otelID(id, 8)
+}
+
+// IsTraceID reports whether id is made of 32 hex characters, so that TraceID keeps it
+func IsTraceID(id string) bool {
+	return isHexID(id, 16)
+}
+
+// IsSpanID reports whether id is made of 16 hex characters, so that SpanID keeps it
+func IsSpanID(id string) bool {
+	return isHexID(id, 8)
+}
+
+// isHexID reports whether id is the hex encoding of size bytes
+func isHexID(id string, size int) bool {
+	if len(id) != 2*size {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// otelID decodes a hex ID of the given byte size, or derives one by hashing
+func otelID(id string, size int) []byte {
+	if len(id) == 2*size {
+		if b, err := hex.DecodeString(id); err == nil {
+			return b
+		}
+	}
+	sum := sha256.Sum256([]byte(id))
+	return sum[:size]
+}
+
+// stringAttr returns a string attribute
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+// intAttr returns an integer attribute
+func intAttr(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}}}
+}
+
+// toInt converts a decoded JSON number to an integer
+func toInt(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	}
+	return 0, false
+}
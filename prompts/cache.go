@@ -0,0 +1,271 @@
+package prompts
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheTTL is the time a cached prompt is considered fresh
+const DefaultCacheTTL = 60 * time.Second
+
+// CacheStats holds the counters of a Cache
+type CacheStats struct {
+	// Hits counts lookups served from a fresh entry
+	Hits int64
+	// StaleHits counts lookups served from an expired entry while it was refreshed
+	StaleHits int64
+	// Misses counts lookups that had to fetch the prompt
+	Misses int64
+	// Refreshes counts background refreshes of expired entries
+	Refreshes int64
+	// RefreshErrors counts background refreshes that failed
+	RefreshErrors int64
+	// Fallbacks counts lookups served from a fallback prompt after a failed fetch
+	Fallbacks int64
+}
+
+// CacheOption is a functional option for configuring a Cache
+type CacheOption func(*Cache)
+
+// WithTTL sets the time a cached prompt is considered fresh
+func WithTTL(ttl time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.ttl = ttl
+	}
+}
+
+// WithFallback sets the prompt returned for the given name when it is not cached
+// and cannot be fetched, e.g. because Langfuse is unreachable
+func WithFallback(name string, prompt *Prompt) CacheOption {
+	return func(c *Cache) {
+		c.fallbacks[name] = prompt
+	}
+}
+
+// WithRefreshErrorHandler sets a function that is called when a background refresh fails
+func WithRefreshErrorHandler(handler func(name string, err error)) CacheOption {
+	return func(c *Cache) {
+		c.onRefreshError = handler
+	}
+}
+
+type cacheKey struct {
+	name    string
+	version int
+	label   string
+}
+
+// fetchCall is a fetch shared by concurrent lookups of the same key
+type fetchCall struct {
+	done   chan struct{}
+	prompt *Prompt
+	err    error
+}
+
+type cacheEntry struct {
+	prompt     *Prompt
+	expiresAt  time.Time
+	refreshing bool
+}
+
+// Cache caches prompts by name, version and label. Expired prompts are served
+// while they are refreshed in the background, so only the first lookup of a
+// prompt waits for the network. Concurrent first lookups share one request.
+//
+// Example:
+//
+//	cache := prompts.NewCache(c.Prompts, prompts.WithTTL(5*time.Minute))
+//	prompt, err := cache.Get(ctx, "support", &prompts.GetParams{Label: types.String("production")})
+type Cache struct {
+	client         *Client
+	ttl            time.Duration
+	fallbacks      map[string]*Prompt
+	onRefreshError func(name string, err error)
+
+	mu       sync.Mutex
+	entries  map[cacheKey]*cacheEntry
+	inflight map[cacheKey]*fetchCall
+	// generations counts the invalidations per name, so that fetches started
+	// before an invalidation do not store their result
+	generations map[string]uint64
+
+	hits, staleHits, misses, refreshes, refreshErrors, fallbackHits atomic.Int64
+}
+
+// NewCache creates a prompt cache backed by the given client
+func NewCache(client *Client, opts ...CacheOption) *Cache {
+	c := &Cache{
+		client:      client,
+		ttl:         DefaultCacheTTL,
+		fallbacks:   map[string]*Prompt{},
+		entries:     map[cacheKey]*cacheEntry{},
+		inflight:    map[cacheKey]*fetchCall{},
+		generations: map[string]uint64{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns the prompt from the cache, fetching it if it is not cached.
+// An expired prompt is returned immediately and refreshed in the background.
+// If the fetch fails and a fallback is configured for the name, the fallback
+// is returned instead of the error.
+func (c *Cache) Get(ctx context.Context, name string, params *GetParams) (*Prompt, error) {
+	key := newCacheKey(name, params)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		if time.Now().Before(entry.expiresAt) {
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return entry.prompt, nil
+		}
+		if !entry.refreshing {
+			entry.refreshing = true
+			go c.refresh(context.WithoutCancel(ctx), key, c.generations[name])
+		}
+		c.mu.Unlock()
+		c.staleHits.Add(1)
+		return entry.prompt, nil
+	}
+	c.mu.Unlock()
+
+	c.misses.Add(1)
+	prompt, err := c.fetch(ctx, key)
+	if err != nil {
+		if fallback, ok := c.fallbacks[name]; ok {
+			c.fallbackHits.Add(1)
+			return fallback, nil
+		}
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// Invalidate removes every cached version and label of the prompt. Fetches and
+// refreshes of the prompt that are still running do not store their result.
+func (c *Cache) Invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[name]++
+	for key := range c.entries {
+		if key.name == name {
+			delete(c.entries, key)
+		}
+	}
+	for key := range c.inflight {
+		if key.name == name {
+			delete(c.inflight, key)
+		}
+	}
+}
+
+// Stats returns the cache counters
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		StaleHits:     c.staleHits.Load(),
+		Misses:        c.misses.Load(),
+		Refreshes:     c.refreshes.Load(),
+		RefreshErrors: c.refreshErrors.Load(),
+		Fallbacks:     c.fallbackHits.Load(),
+	}
+}
+
+// fetch fetches a prompt that is not cached and stores it. Concurrent fetches of
+// the same key share one request, which is not cancelled with ctx so that it can
+// complete for the other callers; ctx only bounds the wait of this caller.
+func (c *Cache) fetch(ctx context.Context, key cacheKey) (*Prompt, error) {
+	c.mu.Lock()
+	call, ok := c.inflight[key]
+	if !ok {
+		call = &fetchCall{done: make(chan struct{})}
+		c.inflight[key] = call
+		generation := c.generations[key.name]
+		go func() {
+			call.prompt, call.err = c.client.Get(context.WithoutCancel(ctx), key.name, key.params())
+			c.mu.Lock()
+			if c.inflight[key] == call {
+				delete(c.inflight, key)
+			}
+			c.mu.Unlock()
+			if call.err == nil {
+				c.store(key, call.prompt, generation)
+			}
+			close(call.done)
+		}()
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.prompt, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refresh fetches an expired prompt and replaces the cached entry. On failure
+// the stale entry is kept and retried on the next lookup.
+func (c *Cache) refresh(ctx context.Context, key cacheKey, generation uint64) {
+	c.refreshes.Add(1)
+	prompt, err := c.client.Get(ctx, key.name, key.params())
+	if err != nil {
+		c.refreshErrors.Add(1)
+		c.mu.Lock()
+		if entry, ok := c.entries[key]; ok {
+			entry.refreshing = false
+		}
+		c.mu.Unlock()
+		if c.onRefreshError != nil {
+			c.onRefreshError(key.name, err)
+		}
+		return
+	}
+	c.store(key, prompt, generation)
+}
+
+// store caches the prompt under the key, unless the prompt was invalidated
+// since the generation the prompt was fetched in
+func (c *Cache) store(key cacheKey, prompt *Prompt, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[key.name] != generation {
+		return
+	}
+	c.entries[key] = &cacheEntry{prompt: prompt, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// newCacheKey returns the cache key of a lookup
+func newCacheKey(name string, params *GetParams) cacheKey {
+	key := cacheKey{name: name}
+	if params != nil {
+		if params.Version != nil {
+			key.version = *params.Version
+		}
+		if params.Label != nil {
+			key.label = *params.Label
+		}
+	}
+	return key
+}
+
+// params returns the lookup parameters of the key. Versions start at 1, so a
+// zero version means no version was requested.
+func (k cacheKey) params() *GetParams {
+	params := &GetParams{}
+	if k.version != 0 {
+		version := k.version
+		params.Version = &version
+	}
+	if k.label != "" {
+		label := k.label
+		params.Label = &label
+	}
+	return params
+}
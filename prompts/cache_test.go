@@ -0,0 +1,168 @@
+package prompts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestCache(t *testing.T) {
+	var (
+		calls     atomic.Int32
+		healthy   atomic.Bool
+		lastLabel atomic.Value
+	)
+	healthy.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastLabel.Store(r.URL.Query().Get("label"))
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"name":"support","version":1,"type":"text","prompt":"Hi {{name}}"}`))
+	}))
+	defer server.Close()
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	t.Run("serves stale entries while refreshing", func(t *testing.T) {
+		calls.Store(0)
+		cache := NewCache(client, WithTTL(time.Millisecond))
+		ctx := context.Background()
+
+		if _, err := cache.Get(ctx, "support", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+		prompt, err := cache.Get(ctx, "support", nil)
+		if err != nil || prompt.Text == nil {
+			t.Fatalf("expected the stale prompt, got %v, %v", prompt, err)
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for calls.Load() < 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		stats := cache.Stats()
+		if stats.Misses != 1 || stats.StaleHits != 1 || calls.Load() != 2 {
+			t.Errorf("unexpected stats %+v after %d requests", stats, calls.Load())
+		}
+	})
+
+	t.Run("keys by version and label", func(t *testing.T) {
+		calls.Store(0)
+		cache := NewCache(client)
+		ctx := context.Background()
+
+		cache.Get(ctx, "support", nil)
+		cache.Get(ctx, "support", nil)
+		cache.Get(ctx, "support", &GetParams{Label: types.String("production")})
+		if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 2 {
+			t.Errorf("unexpected stats %+v", stats)
+		}
+
+		cache.Invalidate("support")
+		cache.Get(ctx, "support", nil)
+		if stats := cache.Stats(); stats.Misses != 3 {
+			t.Errorf("expected a miss after invalidation, got %+v", stats)
+		}
+	})
+
+	t.Run("returns the fallback when the fetch fails", func(t *testing.T) {
+		healthy.Store(false)
+		defer healthy.Store(true)
+
+		fallback := &Prompt{Type: PromptTypeText, Text: &Text{Name: "support", Prompt: "Hello {{name}}"}}
+		cache := NewCache(client, WithFallback("support", fallback))
+
+		prompt, err := cache.Get(context.Background(), "support", nil)
+		if err != nil || prompt != fallback {
+			t.Fatalf("expected the fallback, got %v, %v", prompt, err)
+		}
+		if _, err := cache.Get(context.Background(), "other", nil); err == nil {
+			t.Error("expected an error without a fallback")
+		}
+		if stats := cache.Stats(); stats.Fallbacks != 1 {
+			t.Errorf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("shares concurrent fetches", func(t *testing.T) {
+		calls.Store(0)
+		cache := NewCache(client)
+		params := &GetParams{Label: types.String("production")}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := cache.Get(context.Background(), "support", params); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if calls.Load() != 1 {
+			t.Errorf("expected a single request, got %d", calls.Load())
+		}
+
+		// The refresh rebuilds the lookup from the key, so the caller may reuse params
+		calls.Store(0)
+		cache = NewCache(client, WithTTL(time.Nanosecond))
+		cache.Get(context.Background(), "support", params)
+		time.Sleep(time.Millisecond)
+		cache.Get(context.Background(), "support", params)
+		params.Label = types.String("staging")
+
+		deadline := time.Now().Add(time.Second)
+		for calls.Load() < 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if calls.Load() != 2 || lastLabel.Load() != "production" {
+			t.Errorf("expected the refresh to request the production label, got %v after %d requests", lastLabel.Load(), calls.Load())
+		}
+	})
+}
+
+func TestCacheInvalidateDuringFetch(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			<-release
+		}
+		w.Write([]byte(`{"name":"support","version":1,"type":"text","prompt":"Hi {{name}}"}`))
+	}))
+	defer server.Close()
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	cache := NewCache(client)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.Get(context.Background(), "support", nil)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for calls.Load() < 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The prompt fetched before the invalidation must not be cached
+	cache.Invalidate("support")
+	close(release)
+	<-done
+	cache.Get(context.Background(), "support", nil)
+	if stats := cache.Stats(); stats.Misses != 2 || calls.Load() != 2 {
+		t.Errorf("expected a miss after the invalidation, got %+v after %d requests", stats, calls.Load())
+	}
+}
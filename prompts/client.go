@@ -3,11 +3,13 @@ package prompts
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 
 	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
 )
 
 // Client provides methods for prompt operations
@@ -22,8 +24,44 @@ func NewClient(httpClient *core.HTTPClient) *Client {
 	}
 }
 
-// Get retrieves a prompt by name and version or label
-func (c *Client) Get(ctx context.Context, promptName string, params *GetParams) (interface{}, error) {
+// Get retrieves a prompt by name and version or label.
+// The returned prompt holds either a Chat or a Text prompt, according to its type.
+func (c *Client) Get(ctx context.Context, promptName string, params *GetParams) (*Prompt, error) {
+	var response Prompt
+	if err := c.httpClient.DoRequest(ctx, http.MethodGet, getPath(promptName, params), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetChat retrieves a chat prompt by name and version or label.
+// It returns ErrPromptType if the prompt is a text prompt.
+func (c *Client) GetChat(ctx context.Context, promptName string, params *GetParams) (*Chat, error) {
+	prompt, err := c.Get(ctx, promptName, params)
+	if err != nil {
+		return nil, err
+	}
+	if prompt.Chat == nil {
+		return nil, fmt.Errorf("%w: %s is a %s prompt", ErrPromptType, promptName, prompt.Type)
+	}
+	return prompt.Chat, nil
+}
+
+// GetText retrieves a text prompt by name and version or label.
+// It returns ErrPromptType if the prompt is a chat prompt.
+func (c *Client) GetText(ctx context.Context, promptName string, params *GetParams) (*Text, error) {
+	prompt, err := c.Get(ctx, promptName, params)
+	if err != nil {
+		return nil, err
+	}
+	if prompt.Text == nil {
+		return nil, fmt.Errorf("%w: %s is a %s prompt", ErrPromptType, promptName, prompt.Type)
+	}
+	return prompt.Text, nil
+}
+
+// getPath builds the path for getting a prompt with the given parameters
+func getPath(promptName string, params *GetParams) string {
 	path := "/api/public/prompts/" + url.PathEscape(promptName)
 	if params != nil {
 		query := url.Values{}
@@ -37,12 +75,7 @@ func (c *Client) Get(ctx context.Context, promptName string, params *GetParams)
 			path += "?" + query.Encode()
 		}
 	}
-
-	var response interface{}
-	if err := c.httpClient.DoRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
-		return nil, err
-	}
-	return response, nil
+	return path
 }
 
 // List retrieves all prompts with pagination
@@ -94,3 +127,26 @@ func (c *Client) CreateText(ctx context.Context, req *CreateTextRequest) (*Text,
 	}
 	return &response, nil
 }
+
+// Iter returns an iterator over all prompts matching params, fetching pages lazily.
+// params.Page and params.Limit set the first page and the page size.
+func (c *Client) Iter(ctx context.Context, params *ListParams, opts ...types.IteratorOption) *types.Iterator[Meta] {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	if p.Page != nil {
+		opts = append([]types.IteratorOption{types.WithStartPage(*p.Page)}, opts...)
+	}
+	if p.Limit != nil {
+		opts = append([]types.IteratorOption{types.WithPageSize(*p.Limit)}, opts...)
+	}
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Meta, types.MetaResponse, error) {
+		p.Page, p.Limit = &page, &limit
+		response, err := c.List(ctx, &p)
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
@@ -0,0 +1,152 @@
+package prompts
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// variablePattern matches {{variable}} placeholders
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// CompileError reports the variables a template uses that were not provided,
+// and the provided variables the template does not use. The compiled result
+// is still returned alongside it, with missing placeholders left in place.
+type CompileError struct {
+	Missing []string
+	Unused  []string
+}
+
+// Error returns the missing and unused variables
+func (e *CompileError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing variables: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unused) > 0 {
+		parts = append(parts, "unused variables: "+strings.Join(e.Unused, ", "))
+	}
+	return "prompt compilation failed: " + strings.Join(parts, "; ")
+}
+
+// compiler substitutes variables and tracks which ones were used
+type compiler struct {
+	vars    map[string]interface{}
+	used    map[string]bool
+	missing map[string]bool
+}
+
+func newCompiler(vars map[string]interface{}) *compiler {
+	return &compiler{vars: vars, used: map[string]bool{}, missing: map[string]bool{}}
+}
+
+// substitute replaces the placeholders of the template with their values
+func (c *compiler) substitute(template string) string {
+	return variablePattern.ReplaceAllStringFunc(template, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		value, ok := c.vars[name]
+		if !ok {
+			c.missing[name] = true
+			return match
+		}
+		c.used[name] = true
+		if s, ok := value.(string); ok {
+			return s
+		}
+		return fmt.Sprint(value)
+	})
+}
+
+// err returns a *CompileError if variables were missing or unused
+func (c *compiler) err() error {
+	cerr := &CompileError{}
+	for name := range c.missing {
+		cerr.Missing = append(cerr.Missing, name)
+	}
+	for name := range c.vars {
+		if !c.used[name] {
+			cerr.Unused = append(cerr.Unused, name)
+		}
+	}
+	if len(cerr.Missing) == 0 && len(cerr.Unused) == 0 {
+		return nil
+	}
+	sort.Strings(cerr.Missing)
+	sort.Strings(cerr.Unused)
+	return cerr
+}
+
+// Variables returns the names of the variables used by the template, in order of first use
+func (t *Text) Variables() []string {
+	return variables(t.Prompt)
+}
+
+// Compile substitutes the {{variable}} placeholders of the template.
+// Values that are not strings are formatted with fmt.Sprint. Missing and
+// unused variables are reported as a *CompileError.
+func (t *Text) Compile(vars map[string]interface{}) (string, error) {
+	c := newCompiler(vars)
+	result := c.substitute(t.Prompt)
+	return result, c.err()
+}
+
+// Variables returns the names of the variables and placeholders used by the
+// messages, in order of first use
+func (c *Chat) Variables() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, message := range c.Prompt {
+		candidates := variables(message.Content)
+		if message.Type == ChatMessageTypePlaceholder {
+			candidates = []string{message.Name}
+		}
+		for _, name := range candidates {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Compile substitutes the {{variable}} placeholders of every message and expands
+// placeholder messages into the []ChatMessage passed under their name. Missing and
+// unused variables are reported as a *CompileError.
+func (c *Chat) Compile(vars map[string]interface{}) ([]ChatMessage, error) {
+	comp := newCompiler(vars)
+	messages := make([]ChatMessage, 0, len(c.Prompt))
+	for _, message := range c.Prompt {
+		if message.Type != ChatMessageTypePlaceholder {
+			messages = append(messages, ChatMessage{Role: message.Role, Content: comp.substitute(message.Content)})
+			continue
+		}
+
+		value, ok := vars[message.Name]
+		if !ok {
+			comp.missing[message.Name] = true
+			continue
+		}
+		expanded, ok := value.([]ChatMessage)
+		if !ok {
+			return nil, fmt.Errorf("placeholder %s must be a []ChatMessage, got %T", message.Name, value)
+		}
+		comp.used[message.Name] = true
+		messages = append(messages, expanded...)
+	}
+	return messages, comp.err()
+}
+
+// variables returns the names of the variables used by the template, in order of first use
+func variables(template string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, match := range variablePattern.FindAllStringSubmatch(template, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
@@ -0,0 +1,57 @@
+package prompts
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTextCompile(t *testing.T) {
+	text := &Text{Prompt: "Translate {{ text }} to {{language}}. Answer in {{language}}."}
+
+	got, err := text.Compile(map[string]interface{}{"text": "hello", "language": "French"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Translate hello to French. Answer in French."; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	got, err = text.Compile(map[string]interface{}{"text": "hello", "tone": "formal"})
+	var cerr *CompileError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected a CompileError, got %v", err)
+	}
+	if len(cerr.Missing) != 1 || cerr.Missing[0] != "language" || len(cerr.Unused) != 1 || cerr.Unused[0] != "tone" {
+		t.Errorf("unexpected compile error: %+v", cerr)
+	}
+	if want := "Translate hello to {{language}}. Answer in {{language}}."; got != want {
+		t.Errorf("expected missing placeholders to be kept, got %q", got)
+	}
+}
+
+func TestChatCompile(t *testing.T) {
+	chat := &Chat{Prompt: []ChatMessage{
+		{Role: "system", Content: "You are a {{persona}}."},
+		{Type: ChatMessageTypePlaceholder, Name: "history"},
+		{Role: "user", Content: "{{question}}"},
+	}}
+	if vars := chat.Variables(); len(vars) != 3 || vars[1] != "history" {
+		t.Errorf("unexpected variables: %v", vars)
+	}
+
+	history := []ChatMessage{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}
+	messages, err := chat.Compile(map[string]interface{}{"persona": "pirate", "history": history, "question": "where?"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 4 || messages[0].Content != "You are a pirate." || messages[2].Content != "hello" || messages[3].Content != "where?" {
+		t.Errorf("unexpected messages: %+v", messages)
+	}
+
+	if _, err := chat.Compile(map[string]interface{}{"persona": "pirate", "question": "where?"}); err == nil {
+		t.Error("expected an error for the missing placeholder")
+	}
+	if _, err := chat.Compile(map[string]interface{}{"persona": "pirate", "history": "oops", "question": "where?"}); err == nil {
+		t.Error("expected an error for a placeholder that is not a message list")
+	}
+}
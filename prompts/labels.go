@@ -0,0 +1,92 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// LabelLatest is the label Langfuse assigns to the newest version of a prompt
+const LabelLatest = "latest"
+
+// UpdateVersion replaces the labels of a prompt version. A label can only be on
+// one version of a prompt, so setting it moves it from the version that had it.
+func (c *Client) UpdateVersion(ctx context.Context, promptName string, version int, req *UpdateVersionRequest) (*Prompt, error) {
+	path := "/api/public/v2/prompts/" + url.PathEscape(promptName) + "/versions/" + strconv.Itoa(version)
+
+	var response Prompt
+	if err := c.httpClient.DoRequest(ctx, http.MethodPatch, path, req, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Promote moves the label to the given version of the prompt, keeping the other
+// labels of that version. The version is fetched first, so promoting a version
+// that does not exist fails without changing any label.
+func (c *Client) Promote(ctx context.Context, promptName string, version int, label string) (*Prompt, error) {
+	prompt, err := c.Get(ctx, promptName, &GetParams{Version: &version})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version %d of prompt %s: %w", version, promptName, err)
+	}
+
+	labels := assignableLabels(prompt.Labels())
+	for _, l := range labels {
+		if l == label {
+			return prompt, nil
+		}
+	}
+	return c.UpdateVersion(ctx, promptName, version, &UpdateVersionRequest{NewLabels: append(labels, label)})
+}
+
+// RemoveLabels removes the labels from the given version of the prompt
+func (c *Client) RemoveLabels(ctx context.Context, promptName string, version int, labels ...string) (*Prompt, error) {
+	prompt, err := c.Get(ctx, promptName, &GetParams{Version: &version})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version %d of prompt %s: %w", version, promptName, err)
+	}
+
+	remove := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		remove[l] = true
+	}
+	kept := []string{}
+	for _, l := range assignableLabels(prompt.Labels()) {
+		if !remove[l] {
+			kept = append(kept, l)
+		}
+	}
+	return c.UpdateVersion(ctx, promptName, version, &UpdateVersionRequest{NewLabels: kept})
+}
+
+// Delete deletes the versions of a prompt matching params, or every version if params is nil
+func (c *Client) Delete(ctx context.Context, promptName string, params *DeleteParams) error {
+	path := "/api/public/v2/prompts/" + url.PathEscape(promptName)
+	if params != nil {
+		query := url.Values{}
+		if params.Version != nil {
+			query.Set("version", strconv.Itoa(*params.Version))
+		}
+		if params.Label != nil {
+			query.Set("label", *params.Label)
+		}
+		if len(query) > 0 {
+			path += "?" + query.Encode()
+		}
+	}
+
+	return c.httpClient.DoRequest(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// assignableLabels returns the labels without "latest", which Langfuse manages itself
+func assignableLabels(labels []string) []string {
+	result := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if l != LabelLatest {
+			result = append(result, l)
+		}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package prompts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+)
+
+func TestPromote(t *testing.T) {
+	var patched *UpdateVersionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("version") == "7":
+			w.Write([]byte(`{"name":"support","version":7,"type":"text","prompt":"Hi","labels":["staging","latest"]}`))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Prompt not found"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/public/v2/prompts/support/versions/7":
+			patched = &UpdateVersionRequest{}
+			json.NewDecoder(r.Body).Decode(patched)
+			w.Write([]byte(`{"name":"support","version":7,"type":"text","prompt":"Hi","labels":["staging","production","latest"]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+
+	prompt, err := client.Promote(context.Background(), "support", 7, "production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched == nil || len(patched.NewLabels) != 2 || patched.NewLabels[1] != "production" {
+		t.Errorf("expected the existing labels plus production, got %+v", patched)
+	}
+	if len(prompt.Labels()) != 3 {
+		t.Errorf("unexpected labels: %v", prompt.Labels())
+	}
+
+	patched = nil
+	if _, err := client.Promote(context.Background(), "support", 8, "production"); !core.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if patched != nil {
+		t.Error("expected no update for a missing version")
+	}
+}
@@ -0,0 +1,151 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+)
+
+var (
+	_ observations.PromptReference = (*Chat)(nil)
+	_ observations.PromptReference = (*Text)(nil)
+	_ observations.PromptReference = (*Prompt)(nil)
+)
+
+// ErrPromptType is returned when a prompt has a different type than requested
+var ErrPromptType = errors.New("prompt has a different type")
+
+// Prompt is a chat or text prompt, decoded according to its "type" field.
+// Exactly one of Chat and Text is set.
+type Prompt struct {
+	Type PromptType
+	Chat *Chat
+	Text *Text
+}
+
+// UnmarshalJSON decodes a chat or text prompt depending on its type
+func (p *Prompt) UnmarshalJSON(data []byte) error {
+	var header struct {
+		Type PromptType `json:"type"`
+	}
+	if err := sonic.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	switch header.Type {
+	case PromptTypeChat:
+		var chat Chat
+		if err := sonic.Unmarshal(data, &chat); err != nil {
+			return err
+		}
+		*p = Prompt{Type: PromptTypeChat, Chat: &chat}
+	case PromptTypeText:
+		var text Text
+		if err := sonic.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*p = Prompt{Type: PromptTypeText, Text: &text}
+	default:
+		return fmt.Errorf("unknown prompt type %q", header.Type)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the chat or text prompt
+func (p Prompt) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.Chat != nil:
+		return sonic.Marshal(p.Chat)
+	case p.Text != nil:
+		return sonic.Marshal(p.Text)
+	}
+	return []byte("null"), nil
+}
+
+// Name returns the name of the prompt
+func (p *Prompt) Name() string {
+	switch {
+	case p.Chat != nil:
+		return p.Chat.Name
+	case p.Text != nil:
+		return p.Text.Name
+	}
+	return ""
+}
+
+// Version returns the version of the prompt
+func (p *Prompt) Version() int {
+	switch {
+	case p.Chat != nil:
+		return p.Chat.Version
+	case p.Text != nil:
+		return p.Text.Version
+	}
+	return 0
+}
+
+// Config returns the config of the prompt
+func (p *Prompt) Config() map[string]interface{} {
+	switch {
+	case p.Chat != nil:
+		return p.Chat.Config
+	case p.Text != nil:
+		return p.Text.Config
+	}
+	return nil
+}
+
+// Labels returns the labels of the prompt
+func (p *Prompt) Labels() []string {
+	switch {
+	case p.Chat != nil:
+		return p.Chat.Labels
+	case p.Text != nil:
+		return p.Text.Labels
+	}
+	return nil
+}
+
+// Tags returns the tags of the prompt
+func (p *Prompt) Tags() []string {
+	switch {
+	case p.Chat != nil:
+		return p.Chat.Tags
+	case p.Text != nil:
+		return p.Text.Tags
+	}
+	return nil
+}
+
+// PromptName returns the name of the prompt, for linking generations to it
+func (p *Prompt) PromptName() string {
+	return p.Name()
+}
+
+// PromptVersion returns the version of the prompt, for linking generations to it
+func (p *Prompt) PromptVersion() int {
+	return p.Version()
+}
+
+// PromptName returns the name of the prompt, for linking generations to it
+func (c *Chat) PromptName() string {
+	return c.Name
+}
+
+// PromptVersion returns the version of the prompt, for linking generations to it
+func (c *Chat) PromptVersion() int {
+	return c.Version
+}
+
+// PromptName returns the name of the prompt, for linking generations to it
+func (t *Text) PromptName() string {
+	return t.Name
+}
+
+// PromptVersion returns the version of the prompt, for linking generations to it
+func (t *Text) PromptVersion() int {
+	return t.Version
+}
@@ -0,0 +1,32 @@
+package prompts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPromptUnmarshal(t *testing.T) {
+	var chat Prompt
+	data := `{"name":"support","version":3,"type":"chat","config":{"model":"gpt-4o"},"labels":["production"],"prompt":[{"role":"system","content":"Be brief."}]}`
+	if err := json.Unmarshal([]byte(data), &chat); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if chat.Type != PromptTypeChat || chat.Chat == nil || chat.Text != nil {
+		t.Fatalf("expected a chat prompt, got %+v", chat)
+	}
+	if chat.Version() != 3 || chat.Config()["model"] != "gpt-4o" || chat.Labels()[0] != "production" || chat.Chat.Prompt[0].Content != "Be brief." {
+		t.Errorf("unexpected chat prompt: %+v", chat.Chat)
+	}
+
+	var text Prompt
+	if err := json.Unmarshal([]byte(`{"name":"summary","version":1,"type":"text","prompt":"Summarize {{doc}}"}`), &text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text.Text == nil || text.Text.Prompt != "Summarize {{doc}}" || text.Name() != "summary" {
+		t.Errorf("expected a text prompt, got %+v", text)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"image"}`), &Prompt{}); err == nil {
+		t.Error("expected an error for an unknown prompt type")
+	}
+}
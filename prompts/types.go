@@ -1,39 +1,62 @@
 package prompts
 
-import "time"
+import (
+	"time"
 
-// ChatMessage represents a chat message in a prompt
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// PromptType is the type of a prompt
+type PromptType string
+
+// Prompt types
+const (
+	PromptTypeChat PromptType = "chat"
+	PromptTypeText PromptType = "text"
+)
+
+// Chat message types
+const (
+	ChatMessageTypeChatMessage = "chatmessage"
+	ChatMessageTypePlaceholder = "placeholder"
+)
+
+// ChatMessage represents a chat message in a prompt.
+// Placeholder messages have Type ChatMessageTypePlaceholder and a Name, and are
+// replaced by a list of messages when the prompt is compiled.
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Name    string `json:"name,omitempty"`
 }
 
 // Chat represents a chat-based prompt
 type Chat struct {
-	Name      string        `json:"name"`
-	Version   int           `json:"version"`
-	Config    interface{}   `json:"config,omitempty"`
-	Prompt    []ChatMessage `json:"prompt"`
-	Tags      []string      `json:"tags,omitempty"`
-	Labels    []string      `json:"labels,omitempty"`
-	CreatedAt time.Time     `json:"createdAt"`
-	UpdatedAt time.Time     `json:"updatedAt"`
-	CreatedBy string        `json:"createdBy"`
-	Type      string        `json:"type"`
+	Name      string                 `json:"name"`
+	Version   int                    `json:"version"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+	Prompt    []ChatMessage          `json:"prompt"`
+	Tags      []string               `json:"tags,omitempty"`
+	Labels    []string               `json:"labels,omitempty"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+	CreatedBy string                 `json:"createdBy"`
+	Type      PromptType             `json:"type"`
 }
 
 // Text represents a text-based prompt
 type Text struct {
-	Name      string      `json:"name"`
-	Version   int         `json:"version"`
-	Config    interface{} `json:"config,omitempty"`
-	Prompt    string      `json:"prompt"`
-	Tags      []string    `json:"tags,omitempty"`
-	Labels    []string    `json:"labels,omitempty"`
-	CreatedAt time.Time   `json:"createdAt"`
-	UpdatedAt time.Time   `json:"updatedAt"`
-	CreatedBy string      `json:"createdBy"`
-	Type      string      `json:"type"`
+	Name      string                 `json:"name"`
+	Version   int                    `json:"version"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+	Prompt    string                 `json:"prompt"`
+	Tags      []string               `json:"tags,omitempty"`
+	Labels    []string               `json:"labels,omitempty"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+	CreatedBy string                 `json:"createdBy"`
+	Type      PromptType             `json:"type"`
 }
 
 // CreateChatRequest represents the request body for creating a chat prompt
@@ -71,22 +94,29 @@ type ListParams struct {
 
 // Meta represents prompt metadata
 type Meta struct {
-	Name      string    `json:"name"`
-	Version   int       `json:"version"`
-	Type      string    `json:"type"`
-	Tags      []string  `json:"tags"`
-	Labels    []string  `json:"labels"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	Name      string     `json:"name"`
+	Version   int        `json:"version"`
+	Type      PromptType `json:"type"`
+	Tags      []string   `json:"tags"`
+	Labels    []string   `json:"labels"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
 }
 
 // ListResponse represents a list of prompt metadata
 type ListResponse struct {
-	Data []Meta `json:"data"`
-	Meta struct {
-		Page       int `json:"page"`
-		Limit      int `json:"limit"`
-		TotalItems int `json:"totalItems"`
-		TotalPages int `json:"totalPages"`
-	} `json:"meta"`
+	Data []Meta             `json:"data"`
+	Meta types.MetaResponse `json:"meta"`
+}
+
+// UpdateVersionRequest represents the request body for updating a prompt version
+type UpdateVersionRequest struct {
+	NewLabels []string `json:"newLabels"`
+}
+
+// DeleteParams represents parameters for deleting prompt versions.
+// Without a version or label, every version of the prompt is deleted.
+type DeleteParams struct {
+	Version *int
+	Label   *string
 }
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
 )
 
 // Client provides methods for score operations
@@ -42,34 +45,99 @@ func (c *Client) Get(ctx context.Context, scoreID string) (*Score, error) {
 
 // List retrieves scores with optional filtering
 func (c *Client) List(ctx context.Context, params *ListParams) (*ListResponse, error) {
-	path := "/api/public/scores"
-	if params != nil {
-		query := url.Values{}
-		if params.Page != nil {
-			query.Set("page", strconv.Itoa(*params.Page))
-		}
-		if params.Limit != nil {
-			query.Set("limit", strconv.Itoa(*params.Limit))
-		}
-		if params.TraceID != nil {
-			query.Set("traceId", *params.TraceID)
-		}
-		if params.UserID != nil {
-			query.Set("userId", *params.UserID)
-		}
-		if len(query) > 0 {
-			path += "?" + query.Encode()
-		}
-	}
-
 	var response ListResponse
-	if err := c.httpClient.DoRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+	if err := c.httpClient.DoRequest(ctx, http.MethodGet, listPath(params), nil, &response); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
+// listPath builds the path for listing scores with the given parameters
+func listPath(params *ListParams) string {
+	path := "/api/public/scores"
+	if params == nil {
+		return path
+	}
+
+	query := url.Values{}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.Limit != nil {
+		query.Set("limit", strconv.Itoa(*params.Limit))
+	}
+	if params.TraceID != nil {
+		query.Set("traceId", *params.TraceID)
+	}
+	if params.UserID != nil {
+		query.Set("userId", *params.UserID)
+	}
+	if params.Name != nil {
+		query.Set("name", *params.Name)
+	}
+	if params.Source != nil {
+		query.Set("source", string(*params.Source))
+	}
+	if params.DataType != nil {
+		query.Set("dataType", string(*params.DataType))
+	}
+	if params.ConfigID != nil {
+		query.Set("configId", *params.ConfigID)
+	}
+	if params.ObservationID != nil {
+		query.Set("observationId", *params.ObservationID)
+	}
+	if params.QueueID != nil {
+		query.Set("queueId", *params.QueueID)
+	}
+	if params.FromTimestamp != nil {
+		query.Set("fromTimestamp", params.FromTimestamp.Format(time.RFC3339))
+	}
+	if params.ToTimestamp != nil {
+		query.Set("toTimestamp", params.ToTimestamp.Format(time.RFC3339))
+	}
+	if params.Value != nil {
+		query.Set("value", strconv.FormatFloat(*params.Value, 'f', -1, 64))
+	}
+	if params.Operator != nil {
+		query.Set("operator", *params.Operator)
+	}
+	for _, environment := range params.Environment {
+		query.Add("environment", environment)
+	}
+	if len(params.ScoreIDs) > 0 {
+		query.Set("scoreIds", strings.Join(params.ScoreIDs, ","))
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
+}
+
 // Delete deletes a score by ID
 func (c *Client) Delete(ctx context.Context, scoreID string) error {
 	return c.httpClient.DoRequest(ctx, http.MethodDelete, "/api/public/scores/"+url.PathEscape(scoreID), nil, nil)
 }
+
+// Iter returns an iterator over all scores matching params, fetching pages lazily.
+// params.Page and params.Limit set the first page and the page size.
+func (c *Client) Iter(ctx context.Context, params *ListParams, opts ...types.IteratorOption) *types.Iterator[Score] {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	if p.Page != nil {
+		opts = append([]types.IteratorOption{types.WithStartPage(*p.Page)}, opts...)
+	}
+	if p.Limit != nil {
+		opts = append([]types.IteratorOption{types.WithPageSize(*p.Limit)}, opts...)
+	}
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Score, types.MetaResponse, error) {
+		p.Page, p.Limit = &page, &limit
+		response, err := c.List(ctx, &p)
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
@@ -0,0 +1,22 @@
+package scores
+
+import (
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestListPath(t *testing.T) {
+	source := types.ScoreSourceEval
+	path := listPath(&ListParams{
+		Name:     types.String("accuracy"),
+		Source:   &source,
+		Value:    types.Float64(0.5),
+		Operator: types.String(">="),
+		ScoreIDs: []string{"s1", "s2"},
+	})
+	want := "/api/public/scores?name=accuracy&operator=%3E%3D&scoreIds=s1%2Cs2&source=EVAL&value=0.5"
+	if path != want {
+		t.Errorf("expected %s, got %s", want, path)
+	}
+}
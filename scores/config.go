@@ -0,0 +1,156 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// ErrInvalidScore is returned when a score does not satisfy its config
+var ErrInvalidScore = errors.New("invalid score")
+
+// ListConfigs retrieves score configs
+func (c *Client) ListConfigs(ctx context.Context, params *types.PaginationParams) (*ConfigListResponse, error) {
+	path := "/api/public/score-configs"
+	if params != nil {
+		query := url.Values{}
+		if params.Page != nil {
+			query.Set("page", strconv.Itoa(*params.Page))
+		}
+		if params.Limit != nil {
+			query.Set("limit", strconv.Itoa(*params.Limit))
+		}
+		if len(query) > 0 {
+			path += "?" + query.Encode()
+		}
+	}
+
+	var response ConfigListResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetConfig retrieves a score config by ID
+func (c *Client) GetConfig(ctx context.Context, configID string) (*Config, error) {
+	var response Config
+	if err := c.httpClient.DoRequest(ctx, http.MethodGet, "/api/public/score-configs/"+url.PathEscape(configID), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CreateConfig creates a new score config
+func (c *Client) CreateConfig(ctx context.Context, req *CreateConfigRequest) (*Config, error) {
+	var response Config
+	if err := c.httpClient.DoRequest(ctx, http.MethodPost, "/api/public/score-configs", req, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CreateValidated fetches the config referenced by req.ConfigID, validates the
+// score against it and creates the score only if it is valid
+func (c *Client) CreateValidated(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	if req.ConfigID == nil {
+		return nil, fmt.Errorf("%w: score %q has no config ID", ErrInvalidScore, req.Name)
+	}
+	config, err := c.GetConfig(ctx, *req.ConfigID)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.Validate(req); err != nil {
+		return nil, err
+	}
+	return c.Create(ctx, req)
+}
+
+// Validate checks that the score matches the config: its name and data type, the
+// numeric range, the allowed categories or a boolean value of 0 or 1. Errors wrap
+// ErrInvalidScore.
+func (cfg *Config) Validate(req *CreateRequest) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidScore, fmt.Sprintf(format, args...))
+	}
+
+	if cfg.IsArchived {
+		return invalid("config %s is archived", cfg.ID)
+	}
+	if req.Name != cfg.Name {
+		return invalid("score name %q does not match config name %q", req.Name, cfg.Name)
+	}
+	if req.DataType != nil && *req.DataType != cfg.DataType {
+		return invalid("data type %s does not match config data type %s", *req.DataType, cfg.DataType)
+	}
+
+	switch cfg.DataType {
+	case types.ScoreDataTypeNumeric:
+		value, ok := toFloat(req.Value)
+		if !ok {
+			return invalid("numeric score %q has non-numeric value %v", req.Name, req.Value)
+		}
+		if cfg.MinValue != nil && value < *cfg.MinValue {
+			return invalid("value %v of score %q is below the minimum %v", value, req.Name, *cfg.MinValue)
+		}
+		if cfg.MaxValue != nil && value > *cfg.MaxValue {
+			return invalid("value %v of score %q is above the maximum %v", value, req.Name, *cfg.MaxValue)
+		}
+
+	case types.ScoreDataTypeCategorical:
+		for _, category := range cfg.Categories {
+			if label, ok := req.Value.(string); ok && label == category.Label {
+				return nil
+			}
+			if value, ok := toFloat(req.Value); ok && value == category.Value {
+				return nil
+			}
+		}
+		return invalid("value %v of score %q is not one of the config categories", req.Value, req.Name)
+
+	case types.ScoreDataTypeBoolean:
+		// The API expects 0 or 1; use BooleanScore to convert a Go bool
+		if value, ok := toFloat(req.Value); ok && (value == 0 || value == 1) {
+			return nil
+		}
+		return invalid("boolean score %q has value %v, expected 0 or 1", req.Name, req.Value)
+	}
+
+	return nil
+}
+
+// toFloat converts a numeric value to float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
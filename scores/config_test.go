@@ -0,0 +1,44 @@
+package scores
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestConfigValidate(t *testing.T) {
+	numeric := &Config{ID: "c1", Name: "accuracy", DataType: "NUMERIC", MinValue: types.Float64(0), MaxValue: types.Float64(1)}
+	categorical := &Config{ID: "c2", Name: "tone", DataType: "CATEGORICAL", Categories: []ConfigCategory{{Value: 0, Label: "negative"}, {Value: 1, Label: "positive"}}}
+	boolean := &Config{ID: "c3", Name: "correct", DataType: "BOOLEAN"}
+
+	tests := []struct {
+		name   string
+		config *Config
+		req    CreateRequest
+		valid  bool
+	}{
+		{"numeric in range", numeric, CreateRequest{Name: "accuracy", Value: 0.5}, true},
+		{"numeric above max", numeric, CreateRequest{Name: "accuracy", Value: 1.5}, false},
+		{"numeric with string value", numeric, CreateRequest{Name: "accuracy", Value: "high"}, false},
+		{"name mismatch", numeric, CreateRequest{Name: "precision", Value: 0.5}, false},
+		{"data type mismatch", numeric, CreateRequest{Name: "accuracy", Value: 0.5, DataType: BooleanScore("", "", true).DataType}, false},
+		{"category label", categorical, CreateRequest{Name: "tone", Value: "positive"}, true},
+		{"category value", categorical, CreateRequest{Name: "tone", Value: 0}, true},
+		{"unknown category", categorical, CreateRequest{Name: "tone", Value: "neutral"}, false},
+		{"boolean", boolean, CreateRequest{Name: "correct", Value: 1}, true},
+		{"boolean out of range", boolean, CreateRequest{Name: "correct", Value: 2}, false},
+		{"boolean as Go bool", boolean, CreateRequest{Name: "correct", Value: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(&tt.req)
+			if tt.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidScore) {
+				t.Errorf("expected ErrInvalidScore, got %v", err)
+			}
+		})
+	}
+}
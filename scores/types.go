@@ -11,8 +11,9 @@ type Score struct {
 	ID            string              `json:"id"`
 	Name          string              `json:"name"`
 	Value         interface{}         `json:"value"`
-	DataType      string `json:"dataType"`
-	Source        string   `json:"source"`
+	StringValue   *string             `json:"stringValue,omitempty"`
+	DataType      types.ScoreDataType `json:"dataType"`
+	Source        types.ScoreSource   `json:"source"`
 	Comment       *string             `json:"comment,omitempty"`
 	TraceID       string              `json:"traceId"`
 	ObservationID *string             `json:"observationId,omitempty"`
@@ -27,7 +28,7 @@ type CreateRequest struct {
 	ID            *string              `json:"id,omitempty"`
 	Name          string               `json:"name"`
 	Value         interface{}          `json:"value"`
-	DataType      *string `json:"dataType,omitempty"`
+	DataType      *types.ScoreDataType `json:"dataType,omitempty"`
 	Comment       *string              `json:"comment,omitempty"`
 	TraceID       string               `json:"traceId"`
 	ObservationID *string              `json:"observationId,omitempty"`
@@ -41,10 +42,24 @@ type CreateResponse struct {
 
 // ListParams represents query parameters for listing scores
 type ListParams struct {
-	Page    *int
-	Limit   *int
-	TraceID *string
-	UserID  *string
+	Page          *int
+	Limit         *int
+	TraceID       *string
+	UserID        *string
+	Name          *string
+	Source        *types.ScoreSource
+	DataType      *types.ScoreDataType
+	ConfigID      *string
+	ObservationID *string
+	QueueID       *string
+	FromTimestamp *time.Time
+	ToTimestamp   *time.Time
+	// Value only matches scores whose value compares to it with Operator
+	Value *float64
+	// Operator is the comparison used with Value: "<", ">", "<=", ">=", "!=" or "="
+	Operator    *string
+	Environment []string
+	ScoreIDs    []string
 }
 
 // ListResponse represents a paginated list of scores
@@ -57,11 +72,34 @@ type ListResponse struct {
 type Config struct {
 	ID          string              `json:"id"`
 	Name        string              `json:"name"`
-	DataType    string `json:"dataType"`
+	DataType    types.ScoreDataType `json:"dataType"`
 	Description *string             `json:"description,omitempty"`
 	MinValue    *float64            `json:"minValue,omitempty"`
 	MaxValue    *float64            `json:"maxValue,omitempty"`
-	Categories  []string            `json:"categories,omitempty"`
+	Categories  []ConfigCategory    `json:"categories,omitempty"`
+	IsArchived  bool                `json:"isArchived,omitempty"`
 	CreatedAt   time.Time           `json:"createdAt"`
 	UpdatedAt   time.Time           `json:"updatedAt"`
 }
+
+// ConfigCategory represents an allowed value of a categorical score config
+type ConfigCategory struct {
+	Value float64 `json:"value"`
+	Label string  `json:"label"`
+}
+
+// CreateConfigRequest represents the request body for creating a score config
+type CreateConfigRequest struct {
+	Name        string              `json:"name"`
+	DataType    types.ScoreDataType `json:"dataType"`
+	Description *string             `json:"description,omitempty"`
+	MinValue    *float64            `json:"minValue,omitempty"`
+	MaxValue    *float64            `json:"maxValue,omitempty"`
+	Categories  []ConfigCategory    `json:"categories,omitempty"`
+}
+
+// ConfigListResponse represents a paginated list of score configs
+type ConfigListResponse struct {
+	Data []Config           `json:"data"`
+	Meta types.MetaResponse `json:"meta"`
+}
@@ -0,0 +1,104 @@
+package scores
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// ErrDataType is returned when a score value is read as a different data type
+var ErrDataType = errors.New("score has a different data type")
+
+// NumericScore creates a request for a NUMERIC score on a trace
+func NumericScore(traceID, name string, value float64) *CreateRequest {
+	return newScore(traceID, name, value, types.ScoreDataTypeNumeric)
+}
+
+// CategoricalScore creates a request for a CATEGORICAL score on a trace
+func CategoricalScore(traceID, name, value string) *CreateRequest {
+	return newScore(traceID, name, value, types.ScoreDataTypeCategorical)
+}
+
+// BooleanScore creates a request for a BOOLEAN score on a trace.
+// The API represents boolean values as 1 and 0.
+func BooleanScore(traceID, name string, value bool) *CreateRequest {
+	v := 0.0
+	if value {
+		v = 1
+	}
+	return newScore(traceID, name, v, types.ScoreDataTypeBoolean)
+}
+
+// newScore creates a score request of the given data type
+func newScore(traceID, name string, value interface{}, dataType types.ScoreDataType) *CreateRequest {
+	return &CreateRequest{
+		Name:     name,
+		Value:    value,
+		DataType: &dataType,
+		TraceID:  traceID,
+	}
+}
+
+// FloatValue converts a numeric or boolean score value to a float64, counting
+// true as 1 and false as 0. It reports false for other values.
+func FloatValue(value interface{}) (float64, bool) {
+	if b, ok := value.(bool); ok {
+		if b {
+			return 1, true
+		}
+		return 0, true
+	}
+	return toFloat(value)
+}
+
+// NumericValue returns the value of a NUMERIC score
+func (s *Score) NumericValue() (float64, error) {
+	if s.DataType != types.ScoreDataTypeNumeric {
+		return 0, fmt.Errorf("%w: %s is %s, not %s", ErrDataType, s.Name, s.DataType, types.ScoreDataTypeNumeric)
+	}
+	if v, ok := toFloat(s.Value); ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("score %s has non-numeric value %v", s.Name, s.Value)
+}
+
+// CategoricalValue returns the category label of a CATEGORICAL score
+func (s *Score) CategoricalValue() (string, error) {
+	if s.DataType != types.ScoreDataTypeCategorical {
+		return "", fmt.Errorf("%w: %s is %s, not %s", ErrDataType, s.Name, s.DataType, types.ScoreDataTypeCategorical)
+	}
+	if s.StringValue != nil {
+		return *s.StringValue, nil
+	}
+	if v, ok := s.Value.(string); ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("score %s has no category label", s.Name)
+}
+
+// BooleanValue returns the value of a BOOLEAN score
+func (s *Score) BooleanValue() (bool, error) {
+	if s.DataType != types.ScoreDataTypeBoolean {
+		return false, fmt.Errorf("%w: %s is %s, not %s", ErrDataType, s.Name, s.DataType, types.ScoreDataTypeBoolean)
+	}
+	switch v := s.Value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+	default:
+		if f, ok := toFloat(v); ok {
+			return f != 0, nil
+		}
+	}
+	if s.StringValue != nil {
+		if b, err := strconv.ParseBool(*s.StringValue); err == nil {
+			return b, nil
+		}
+	}
+	return false, fmt.Errorf("score %s has non-boolean value %v", s.Name, s.Value)
+}
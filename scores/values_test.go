@@ -0,0 +1,54 @@
+package scores
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestTypedScores(t *testing.T) {
+	if req := BooleanScore("trace-1", "correct", true); req.Value != 1.0 || *req.DataType != types.ScoreDataTypeBoolean {
+		t.Errorf("unexpected boolean score: %+v", req)
+	}
+
+	var score Score
+	if err := json.Unmarshal([]byte(`{"name":"tone","value":1,"stringValue":"positive","dataType":"CATEGORICAL","source":"API"}`), &score); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if label, err := score.CategoricalValue(); err != nil || label != "positive" {
+		t.Errorf("expected the category label, got %q, %v", label, err)
+	}
+	if _, err := score.NumericValue(); !errors.Is(err, ErrDataType) {
+		t.Errorf("expected ErrDataType, got %v", err)
+	}
+
+	score = Score{Name: "correct", Value: 0.0, DataType: types.ScoreDataTypeBoolean}
+	if v, err := score.BooleanValue(); err != nil || v {
+		t.Errorf("expected false, got %v, %v", v, err)
+	}
+}
+
+func TestFloatValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  float64
+		ok    bool
+	}{
+		{0.5, 0.5, true},
+		{float32(0.25), 0.25, true},
+		{3, 3, true},
+		{int64(-2), -2, true},
+		{uint8(7), 7, true},
+		{true, 1, true},
+		{false, 0, true},
+		{"1", 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := FloatValue(tt.value); got != tt.want || ok != tt.ok {
+			t.Errorf("FloatValue(%#v) = %v, %v; expected %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -54,3 +54,14 @@ func (c *Client) List(ctx context.Context, params *types.PaginationParams) (*Lis
 	}
 	return &response, nil
 }
+
+// Iter returns an iterator over all sessions, fetching pages lazily
+func (c *Client) Iter(ctx context.Context, opts ...types.IteratorOption) *types.Iterator[Session] {
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Session, types.MetaResponse, error) {
+		response, err := c.List(ctx, &types.PaginationParams{Page: &page, Limit: &limit})
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
@@ -0,0 +1,153 @@
+package langfuse
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/scores"
+	"github.com/rohitkeshwani07/langfuse-go/traces"
+)
+
+// Trace is a handle to a trace started with StartTrace. Spans, generations
+// and events started from it are attached to the trace automatically.
+// A Trace is safe for concurrent use.
+type Trace struct {
+	tracer *Tracer
+	id     string
+
+	mu     sync.Mutex
+	update traces.CreateTraceRequest
+	ended  bool
+}
+
+// newTrace creates a trace and records its trace-create event
+func newTrace(tracer *Tracer, name string, opts []Option) *Trace {
+	cfg := newConfig(opts)
+
+	// CreateTraceID only fails if the system random source is unavailable
+	id, _ := CreateTraceID("")
+
+	t := &Trace{tracer: tracer, id: id}
+	t.update.ID = &t.id
+
+	tracer.emit(ingestion.NewTraceCreateEvent(&traces.CreateTraceRequest{
+		ID:        &t.id,
+		Name:      &name,
+		UserID:    cfg.userID,
+		SessionID: cfg.sessionID,
+		Release:   cfg.release,
+		Version:   cfg.version,
+		Metadata:  cfg.metadata,
+		Tags:      cfg.tags,
+		Input:     cfg.input,
+		Output:    cfg.output,
+		Timestamp: &cfg.startTime,
+		Public:    cfg.public,
+	}))
+
+	return t
+}
+
+// ID returns the trace ID
+func (t *Trace) ID() string {
+	return t.id
+}
+
+// StartSpan starts a span at the top level of the trace
+func (t *Trace) StartSpan(name string, opts ...Option) *Span {
+	return newSpan(t.tracer, t.id, nil, name, opts)
+}
+
+// StartGeneration starts a generation at the top level of the trace
+func (t *Trace) StartGeneration(name string, opts ...Option) *Generation {
+	return newGeneration(t.tracer, t.id, nil, name, opts)
+}
+
+// Event records a point-in-time event at the top level of the trace
+func (t *Trace) Event(name string, opts ...Option) {
+	recordEvent(t.tracer, t.id, nil, name, opts)
+}
+
+// Score records a score for the trace
+func (t *Trace) Score(name string, value interface{}, opts ...ScoreOption) {
+	recordScore(t.tracer, t.id, nil, name, value, opts)
+}
+
+// SetInput sets the input of the trace
+func (t *Trace) SetInput(input interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update.Input = input
+}
+
+// SetOutput sets the output of the trace
+func (t *Trace) SetOutput(output interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update.Output = output
+}
+
+// SetMetadata sets the metadata of the trace
+func (t *Trace) SetMetadata(metadata map[string]interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update.Metadata = metadata
+}
+
+// End records the changes made since the trace was started.
+// Calling End more than once has no effect.
+func (t *Trace) End() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ended {
+		return
+	}
+	t.ended = true
+
+	update := t.update
+	t.tracer.emit(ingestion.NewTraceCreateEvent(&update))
+}
+
+// recordEvent records an event-create event
+func recordEvent(tracer *Tracer, traceID string, parentID *string, name string, opts []Option) {
+	cfg := newConfig(opts)
+	id := newObservationID()
+
+	tracer.emit(ingestion.NewEventCreateEvent(&observations.CreateEventRequest{
+		ID:                  &id,
+		TraceID:             &traceID,
+		ParentObservationID: parentID,
+		Name:                &name,
+		StartTime:           &cfg.startTime,
+		Metadata:            cfg.metadata,
+		Input:               cfg.input,
+		Output:              cfg.output,
+		Level:               cfg.level,
+		StatusMessage:       cfg.statusMessage,
+		Version:             cfg.version,
+	}))
+}
+
+// recordScore records a score-create event
+func recordScore(tracer *Tracer, traceID string, observationID *string, name string, value interface{}, opts []ScoreOption) {
+	req := &scores.CreateRequest{
+		Name:          name,
+		Value:         value,
+		TraceID:       traceID,
+		ObservationID: observationID,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	tracer.emit(ingestion.NewScoreCreateEvent(req))
+}
+
+// newObservationID returns a random 16-character lowercase hexadecimal observation ID
+func newObservationID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,121 @@
+package langfuse
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+)
+
+// Tracer records traces and observations and sends them to Langfuse in the
+// background through an ingestion.Batcher.
+//
+// All methods are safe to call on a nil *Tracer, in which case events are dropped.
+type Tracer struct {
+	batcher *ingestion.Batcher
+	onError func(error)
+}
+
+// TracerOption is a functional option for configuring the Tracer
+type TracerOption func(*tracerConfig)
+
+type tracerConfig struct {
+	batcherOpts []ingestion.BatcherOption
+	onError     func(error)
+}
+
+// WithBatcherOptions configures the batcher that sends the tracer's events
+func WithBatcherOptions(opts ...ingestion.BatcherOption) TracerOption {
+	return func(c *tracerConfig) {
+		c.batcherOpts = append(c.batcherOpts, opts...)
+	}
+}
+
+// WithErrorHandler sets a callback for events that could not be queued or sent
+func WithErrorHandler(handler func(error)) TracerOption {
+	return func(c *tracerConfig) {
+		c.onError = handler
+	}
+}
+
+// NewTracer creates a new Tracer that sends events through the given ingestion client.
+// Call Shutdown before the program exits to send the remaining events.
+func NewTracer(client *ingestion.Client, opts ...TracerOption) *Tracer {
+	cfg := &tracerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	batcherOpts := cfg.batcherOpts
+	if cfg.onError != nil {
+		batcherOpts = append([]ingestion.BatcherOption{ingestion.WithErrorHandler(cfg.onError)}, batcherOpts...)
+	}
+
+	return &Tracer{
+		batcher: ingestion.NewBatcher(client, batcherOpts...),
+		onError: cfg.onError,
+	}
+}
+
+// StartTrace starts a new trace with the given name
+func (t *Tracer) StartTrace(ctx context.Context, name string, opts ...Option) *Trace {
+	return newTrace(t, name, opts)
+}
+
+// Flush sends all events recorded so far
+func (t *Tracer) Flush(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	return t.batcher.Flush(ctx)
+}
+
+// Shutdown sends the remaining events and stops the tracer
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	return t.batcher.Shutdown(ctx)
+}
+
+// emit queues an event for sending
+func (t *Tracer) emit(event ingestion.Event) {
+	if t == nil {
+		return
+	}
+	if err := t.batcher.Enqueue(event); err != nil && t.onError != nil {
+		t.onError(err)
+	}
+}
+
+var defaultTracer atomic.Pointer[Tracer]
+
+// SetDefaultTracer sets the tracer used by the package-level StartTrace function
+func SetDefaultTracer(t *Tracer) {
+	defaultTracer.Store(t)
+}
+
+// DefaultTracer returns the tracer set with SetDefaultTracer, or nil
+func DefaultTracer() *Tracer {
+	return defaultTracer.Load()
+}
+
+// StartTrace starts a new trace on the default tracer. If no default tracer
+// is set, the returned trace records nothing.
+//
+// Example:
+//
+//	tracer := langfuse.NewTracer(c.Ingestion)
+//	defer tracer.Shutdown(context.Background())
+//	langfuse.SetDefaultTracer(tracer)
+//
+//	trace := langfuse.StartTrace(ctx, "handle-request", langfuse.WithUserID("user-123"))
+//	defer trace.End()
+//
+//	generation := trace.StartGeneration("completion", langfuse.WithModel("gpt-4o"))
+//	generation.SetOutput(answer)
+//	generation.SetUsage(types.Usage{Input: types.Int(10), Output: types.Int(20)})
+//	generation.End()
+func StartTrace(ctx context.Context, name string, opts ...Option) *Trace {
+	return DefaultTracer().StartTrace(ctx, name, opts...)
+}
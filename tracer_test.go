@@ -0,0 +1,184 @@
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/ingestion"
+	"github.com/rohitkeshwani07/langfuse-go/prompts"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+// sentEvent is an ingestion event as received by the stand-in server
+type sentEvent struct {
+	Type string                 `json:"type"`
+	Body map[string]interface{} `json:"body"`
+}
+
+// newTestTracer returns a tracer backed by a stand-in ingestion endpoint and
+// a function that flushes the tracer and returns every event received so far
+func newTestTracer(t *testing.T) (*Tracer, func() []sentEvent) {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		events []sentEvent
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Batch []sentEvent `json:"batch"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		events = append(events, body.Batch...)
+		mu.Unlock()
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(`{"successes":[],"errors":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := ingestion.NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	tracer := NewTracer(client,
+		WithBatcherOptions(ingestion.WithFlushInterval(time.Hour)),
+		WithErrorHandler(func(err error) { t.Errorf("unexpected tracer error: %v", err) }),
+	)
+	t.Cleanup(func() { tracer.Shutdown(context.Background()) })
+
+	return tracer, func() []sentEvent {
+		if err := tracer.Flush(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]sentEvent(nil), events...)
+	}
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("records nested observations", func(t *testing.T) {
+		tracer, sent := newTestTracer(t)
+
+		trace := tracer.StartTrace(context.Background(), "request", WithUserID("user-1"))
+		span := trace.StartSpan("retrieve")
+		generation := span.StartGeneration("completion", WithModel("gpt-4o"), WithPrompt(&prompts.Text{Name: "qa", Version: 3}))
+		generation.SetOutput("hello")
+		generation.SetUsage(types.Usage{Input: types.Int(3), Output: types.Int(5)})
+		generation.End()
+		span.End()
+		trace.Score("quality", 0.9)
+		trace.SetOutput("done")
+		trace.End()
+
+		events := sent()
+		got := make([]string, len(events))
+		for i, e := range events {
+			got[i] = e.Type
+		}
+		want := []string{"trace-create", "span-create", "generation-create", "generation-update", "span-update", "score-create", "trace-create"}
+		if len(got) != len(want) {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected events %v, got %v", want, got)
+			}
+		}
+
+		if events[0].Body["userId"] != "user-1" || events[0].Body["id"] != trace.ID() {
+			t.Errorf("unexpected trace body: %v", events[0].Body)
+		}
+		if events[2].Body["parentObservationId"] != span.ID() || events[2].Body["traceId"] != trace.ID() {
+			t.Errorf("generation is not attached to the span: %v", events[2].Body)
+		}
+		if events[2].Body["promptName"] != "qa" || events[2].Body["promptVersion"] != 3.0 {
+			t.Errorf("generation is not linked to the prompt: %v", events[2].Body)
+		}
+		if events[3].Body["id"] != generation.ID() || events[3].Body["output"] != "hello" || events[3].Body["endTime"] == nil {
+			t.Errorf("unexpected generation update: %v", events[3].Body)
+		}
+		if events[5].Body["traceId"] != trace.ID() || events[5].Body["value"] != 0.9 {
+			t.Errorf("unexpected score: %v", events[5].Body)
+		}
+		if events[6].Body["output"] != "done" {
+			t.Errorf("unexpected trace update: %v", events[6].Body)
+		}
+	})
+
+	t.Run("ends observations once", func(t *testing.T) {
+		tracer, sent := newTestTracer(t)
+
+		span := tracer.StartTrace(context.Background(), "request").StartSpan("work")
+		span.End()
+		span.End()
+
+		if events := sent(); len(events) != 3 {
+			t.Errorf("expected 3 events, got %d", len(events))
+		}
+	})
+
+	t.Run("without a default tracer records nothing", func(t *testing.T) {
+		trace := StartTrace(context.Background(), "noop")
+		trace.StartSpan("child").End()
+		trace.End()
+
+		if len(trace.ID()) != 32 {
+			t.Errorf("expected a valid trace ID, got %q", trace.ID())
+		}
+	})
+}
+
+func TestStartSpanFromContext(t *testing.T) {
+	tracer, sent := newTestTracer(t)
+
+	trace := tracer.StartTrace(context.Background(), "request")
+	ctx := ContextWithTrace(context.Background(), trace)
+
+	ctx, outer := StartSpan(ctx, "handler")
+	if SpanFromContext(ctx) != outer {
+		t.Fatal("expected the context to carry the new span")
+	}
+
+	var wg sync.WaitGroup
+	inner := make([]*Span, 3)
+	for i := range inner {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, span := StartSpan(ctx, "db-query")
+			span.End()
+			inner[i] = span
+		}(i)
+	}
+	wg.Wait()
+	StartGeneration(ctx, "completion").End()
+	outer.End()
+
+	parents := map[string]string{}
+	for _, e := range sent() {
+		if e.Type == "span-create" || e.Type == "generation-create" {
+			if e.Body["traceId"] != trace.ID() {
+				t.Errorf("observation %v is not attached to the trace", e.Body["id"])
+			}
+			parent, _ := e.Body["parentObservationId"].(string)
+			parents[e.Body["id"].(string)] = parent
+		}
+	}
+
+	if parents[outer.ID()] != "" {
+		t.Errorf("expected the outer span to be at the top level, got parent %q", parents[outer.ID()])
+	}
+	for _, span := range inner {
+		if parents[span.ID()] != outer.ID() {
+			t.Errorf("expected span %s to be a child of the outer span, got parent %q", span.ID(), parents[span.ID()])
+		}
+	}
+	if len(parents) != 5 {
+		t.Errorf("expected 5 observations, got %d", len(parents))
+	}
+}
@@ -3,12 +3,20 @@ package traces
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
 )
 
+// DeleteBatchSize is the number of traces DeleteByUserID deletes per request
+const DeleteBatchSize = 100
+
 // Client provides methods for trace operations
 type Client struct {
 	httpClient *core.HTTPClient
@@ -54,3 +62,136 @@ func (c *Client) GetTree(ctx context.Context, traceID string) (*TraceTree, error
 	}
 	return response.ToTraceTree(), nil
 }
+
+// Delete deletes a trace and its observations
+func (c *Client) Delete(ctx context.Context, traceID string) (*DeleteResponse, error) {
+	var response DeleteResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodDelete, "/api/public/traces/"+url.PathEscape(traceID), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DeleteMany deletes multiple traces and their observations
+func (c *Client) DeleteMany(ctx context.Context, traceIDs []string) (*DeleteResponse, error) {
+	var response DeleteResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodDelete, "/api/public/traces", &DeleteManyRequest{TraceIDs: traceIDs}, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DeleteByUserID deletes every trace of the user, e.g. to honor an erasure request.
+// The trace IDs are collected first and then deleted with DeleteMany in batches
+// of DeleteBatchSize. It returns the number of traces deleted before any error.
+func (c *Client) DeleteByUserID(ctx context.Context, userID string) (int, error) {
+	var traceIDs []string
+	it := c.Iter(ctx, &ListParams{UserID: &userID, Fields: []string{"core"}}, types.WithPageSize(100))
+	for it.Next() {
+		traceIDs = append(traceIDs, it.Item().ID)
+	}
+	if err := it.Err(); err != nil {
+		return 0, fmt.Errorf("failed to list traces of user %s: %w", userID, err)
+	}
+
+	deleted := 0
+	for start := 0; start < len(traceIDs); start += DeleteBatchSize {
+		end := min(start+DeleteBatchSize, len(traceIDs))
+		if _, err := c.DeleteMany(ctx, traceIDs[start:end]); err != nil {
+			return deleted, fmt.Errorf("failed to delete traces of user %s: %w", userID, err)
+		}
+		deleted = end
+	}
+	return deleted, nil
+}
+
+// List retrieves traces with optional filtering
+func (c *Client) List(ctx context.Context, params *ListParams) (*ListResponse, error) {
+	var response ListResponse
+	if err := c.httpClient.DoRequest(ctx, http.MethodGet, listPath(params), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ListIn retrieves traces with optional filtering into the provided output variable.
+// This is an optimization to allow reusing allocated memory and avoid allocations.
+func (c *Client) ListIn(ctx context.Context, params *ListParams, out interface{}) error {
+	return c.httpClient.DoRequest(ctx, http.MethodGet, listPath(params), nil, out)
+}
+
+// listPath builds the path for listing traces with the given parameters
+func listPath(params *ListParams) string {
+	path := "/api/public/traces"
+	if params == nil {
+		return path
+	}
+
+	query := url.Values{}
+	if params.Page != nil {
+		query.Set("page", strconv.Itoa(*params.Page))
+	}
+	if params.Limit != nil {
+		query.Set("limit", strconv.Itoa(*params.Limit))
+	}
+	if params.UserID != nil {
+		query.Set("userId", *params.UserID)
+	}
+	if params.Name != nil {
+		query.Set("name", *params.Name)
+	}
+	if params.SessionID != nil {
+		query.Set("sessionId", *params.SessionID)
+	}
+	if params.FromTimestamp != nil {
+		query.Set("fromTimestamp", params.FromTimestamp.Format(time.RFC3339))
+	}
+	if params.ToTimestamp != nil {
+		query.Set("toTimestamp", params.ToTimestamp.Format(time.RFC3339))
+	}
+	if params.OrderBy != nil {
+		query.Set("orderBy", params.OrderBy.String())
+	}
+	for _, tag := range params.Tags {
+		query.Add("tags", tag)
+	}
+	if params.Version != nil {
+		query.Set("version", *params.Version)
+	}
+	if params.Release != nil {
+		query.Set("release", *params.Release)
+	}
+	for _, environment := range params.Environment {
+		query.Add("environment", environment)
+	}
+	if len(params.Fields) > 0 {
+		query.Set("fields", strings.Join(params.Fields, ","))
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path
+}
+
+// Iter returns an iterator over all traces matching params, fetching pages lazily.
+// params.Page and params.Limit set the first page and the page size.
+func (c *Client) Iter(ctx context.Context, params *ListParams, opts ...types.IteratorOption) *types.Iterator[Trace] {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	if p.Page != nil {
+		opts = append([]types.IteratorOption{types.WithStartPage(*p.Page)}, opts...)
+	}
+	if p.Limit != nil {
+		opts = append([]types.IteratorOption{types.WithPageSize(*p.Limit)}, opts...)
+	}
+	return types.NewIterator(ctx, func(ctx context.Context, page, limit int) ([]Trace, types.MetaResponse, error) {
+		p.Page, p.Limit = &page, &limit
+		response, err := c.List(ctx, &p)
+		if err != nil {
+			return nil, types.MetaResponse{}, err
+		}
+		return response.Data, response.Meta, nil
+	}, opts...)
+}
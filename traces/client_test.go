@@ -0,0 +1,84 @@
+package traces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rohitkeshwani07/langfuse-go/core"
+	"github.com/rohitkeshwani07/langfuse-go/types"
+)
+
+func TestListPath(t *testing.T) {
+	from := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		params *ListParams
+		want   string
+	}{
+		{"no params", nil, "/api/public/traces"},
+		{"empty params", &ListParams{}, "/api/public/traces"},
+		{"pagination", &ListParams{Page: types.Int(2), Limit: types.Int(50)}, "/api/public/traces?limit=50&page=2"},
+		{"user and session", &ListParams{UserID: types.String("user-1"), SessionID: types.String("session 1")}, "/api/public/traces?sessionId=session+1&userId=user-1"},
+		{"name", &ListParams{Name: types.String("chat")}, "/api/public/traces?name=chat"},
+		{"repeated tags", &ListParams{Tags: []string{"prod", "beta"}}, "/api/public/traces?tags=prod&tags=beta"},
+		{"repeated environments", &ListParams{Environment: []string{"production", "staging"}}, "/api/public/traces?environment=production&environment=staging"},
+		{"release and version", &ListParams{Release: types.String("v1.2.0"), Version: types.String("3")}, "/api/public/traces?release=v1.2.0&version=3"},
+		{"time range", &ListParams{FromTimestamp: &from, ToTimestamp: &to}, "/api/public/traces?fromTimestamp=2024-01-02T03%3A04%3A05Z&toTimestamp=2024-01-02T04%3A04%3A05Z"},
+		{"order by", &ListParams{OrderBy: &types.Sort{Column: "timestamp", Order: "desc"}}, "/api/public/traces?orderBy=timestamp.desc"},
+		{"fields", &ListParams{Fields: []string{"core", "scores"}}, "/api/public/traces?fields=core%2Cscores"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if path := listPath(tt.params); path != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, path)
+			}
+		})
+	}
+}
+
+func TestDeleteByUserID(t *testing.T) {
+	const total = 150
+
+	var deleted [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("userId") != "user-1" {
+				t.Errorf("expected the userId filter, got %q", r.URL.RawQuery)
+			}
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			response := ListResponse{Meta: types.MetaResponse{Page: page, Limit: limit, TotalItems: total, TotalPages: (total + limit - 1) / limit}}
+			for i := (page - 1) * limit; i < page*limit && i < total; i++ {
+				response.Data = append(response.Data, Trace{ID: fmt.Sprintf("trace-%d", i)})
+			}
+			json.NewEncoder(w).Encode(response)
+		case http.MethodDelete:
+			var body DeleteManyRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			deleted = append(deleted, body.TraceIDs)
+			w.Write([]byte(`{"message":"Traces deleted"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(core.NewHTTPClient("pk", "sk", core.WithBaseURL(server.URL)))
+	n, err := client.DeleteByUserID(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != total {
+		t.Errorf("expected %d deleted traces, got %d", total, n)
+	}
+	if len(deleted) != 2 || len(deleted[0]) != DeleteBatchSize || deleted[1][len(deleted[1])-1] != "trace-149" {
+		t.Errorf("unexpected delete batches: %d", len(deleted))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rohitkeshwani07/langfuse-go/observations"
+	"github.com/rohitkeshwani07/langfuse-go/types"
 )
 
 // Trace represents a trace in Langfuse
@@ -65,6 +66,43 @@ type UpdateTraceRequest struct {
 	Public    *bool                  `json:"public,omitempty"`
 }
 
+// ListParams represents query parameters for listing traces
+type ListParams struct {
+	Page          *int
+	Limit         *int
+	UserID        *string
+	Name          *string
+	SessionID     *string
+	FromTimestamp *time.Time
+	ToTimestamp   *time.Time
+	// OrderBy sorts the traces, e.g. {Column: "timestamp", Order: "desc"}
+	OrderBy *types.Sort
+	// Tags only matches traces that have all of the given tags
+	Tags        []string
+	Version     *string
+	Release     *string
+	Environment []string
+	// Fields selects the field groups to return, e.g. "core", "io", "scores",
+	// "observations" and "metrics". All groups are returned when empty.
+	Fields []string
+}
+
+// ListResponse represents a paginated list of traces
+type ListResponse struct {
+	Data []Trace            `json:"data"`
+	Meta types.MetaResponse `json:"meta"`
+}
+
+// DeleteResponse represents the response of a trace deletion
+type DeleteResponse struct {
+	Message string `json:"message"`
+}
+
+// DeleteManyRequest represents the request body for deleting multiple traces
+type DeleteManyRequest struct {
+	TraceIDs []string `json:"traceIds"`
+}
+
 // ObservationNode represents a node in the trace tree structure.
 // This is a compact representation that excludes input/output fields to reduce memory allocation
 // when working with large trace trees.
@@ -61,3 +61,11 @@ type Sort struct {
 	Column string `json:"column"`
 	Order  string `json:"order"`
 }
+
+// String returns the sort in the "column.order" form used by orderBy query parameters
+func (s Sort) String() string {
+	if s.Order == "" {
+		return s.Column
+	}
+	return s.Column + "." + s.Order
+}
@@ -0,0 +1,21 @@
+package types
+
+// ScoreDataType is the data type of a score
+type ScoreDataType string
+
+// Score data types
+const (
+	ScoreDataTypeNumeric     ScoreDataType = "NUMERIC"
+	ScoreDataTypeCategorical ScoreDataType = "CATEGORICAL"
+	ScoreDataTypeBoolean     ScoreDataType = "BOOLEAN"
+)
+
+// ScoreSource is the origin of a score
+type ScoreSource string
+
+// Score sources
+const (
+	ScoreSourceAPI        ScoreSource = "API"
+	ScoreSourceEval       ScoreSource = "EVAL"
+	ScoreSourceAnnotation ScoreSource = "ANNOTATION"
+)
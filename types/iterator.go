@@ -0,0 +1,203 @@
+package types
+
+import "context"
+
+// DefaultPageSize is the number of items an Iterator requests per page
+const DefaultPageSize = 50
+
+// PageFetcher fetches one page of a paginated endpoint. Pages start at 1.
+type PageFetcher[T any] func(ctx context.Context, page, limit int) ([]T, MetaResponse, error)
+
+// IteratorOption is a functional option for configuring an Iterator
+type IteratorOption func(*iteratorConfig)
+
+type iteratorConfig struct {
+	pageSize  int
+	startPage int
+	maxItems  int
+	prefetch  bool
+}
+
+// WithPageSize sets the number of items requested per page
+func WithPageSize(n int) IteratorOption {
+	return func(c *iteratorConfig) {
+		c.pageSize = n
+	}
+}
+
+// WithStartPage sets the first page to fetch
+func WithStartPage(page int) IteratorOption {
+	return func(c *iteratorConfig) {
+		c.startPage = page
+	}
+}
+
+// WithMaxItems stops the iteration after n items
+func WithMaxItems(n int) IteratorOption {
+	return func(c *iteratorConfig) {
+		c.maxItems = n
+	}
+}
+
+// WithPrefetch fetches the next page in the background while the current one is consumed
+func WithPrefetch() IteratorOption {
+	return func(c *iteratorConfig) {
+		c.prefetch = true
+	}
+}
+
+type pageResult[T any] struct {
+	items []T
+	meta  MetaResponse
+	err   error
+}
+
+// Iterator walks the items of a paginated endpoint, fetching pages lazily.
+// The iteration stops at the last page, after the configured maximum number
+// of items, on the first error, or when the context is cancelled.
+//
+// Example:
+//
+//	it := c.Observations.Iter(ctx, &observations.ListParams{TraceID: types.String(id)})
+//	for it.Next() {
+//	    observation := it.Item()
+//	    ...
+//	}
+//	if err := it.Err(); err != nil {
+//	    ...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFetcher[T]
+	cfg   iteratorConfig
+
+	page  int
+	items []T
+	index int
+	item  T
+	count int
+	last  bool
+	err   error
+
+	// next receives the prefetched page, if one is being fetched
+	next chan pageResult[T]
+}
+
+// NewIterator creates an iterator over the pages returned by fetch
+func NewIterator[T any](ctx context.Context, fetch PageFetcher[T], opts ...IteratorOption) *Iterator[T] {
+	cfg := iteratorConfig{
+		pageSize:  DefaultPageSize,
+		startPage: 1,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		cfg:   cfg,
+		page:  cfg.startPage - 1,
+	}
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when the iteration is over; check Err for the reason.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	if it.cfg.maxItems > 0 && it.count >= it.cfg.maxItems {
+		return false
+	}
+
+	for it.index >= len(it.items) {
+		if it.last {
+			return false
+		}
+		result := it.nextPage()
+		if result.err != nil {
+			it.err = result.err
+			return false
+		}
+		it.receive(result)
+	}
+
+	it.item = it.items[it.index]
+	it.index++
+	it.count++
+	return true
+}
+
+// Item returns the current item
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator and returns the remaining items
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// nextPage returns the prefetched page, or fetches the next page
+func (it *Iterator[T]) nextPage() pageResult[T] {
+	if it.next == nil {
+		return it.fetchPage(it.page + 1)
+	}
+
+	next := it.next
+	it.next = nil
+	select {
+	case result := <-next:
+		return result
+	case <-it.ctx.Done():
+		return pageResult[T]{err: it.ctx.Err()}
+	}
+}
+
+// fetchPage fetches the given page
+func (it *Iterator[T]) fetchPage(page int) pageResult[T] {
+	items, meta, err := it.fetch(it.ctx, page, it.cfg.pageSize)
+	return pageResult[T]{items: items, meta: meta, err: err}
+}
+
+// receive makes the page the current one and starts prefetching the page after it
+func (it *Iterator[T]) receive(result pageResult[T]) {
+	it.page++
+	it.items, it.index = result.items, 0
+
+	switch {
+	case len(result.items) == 0:
+		it.last = true
+	case result.meta.TotalPages > 0:
+		it.last = it.page >= result.meta.TotalPages
+	default:
+		it.last = len(result.items) < it.cfg.pageSize
+	}
+	if it.cfg.maxItems > 0 && it.count+len(result.items) >= it.cfg.maxItems {
+		it.last = true
+	}
+
+	if it.cfg.prefetch && !it.last {
+		// Buffered so the fetch completes even if the iterator is abandoned
+		next := make(chan pageResult[T], 1)
+		page := it.page + 1
+		go func() {
+			next <- it.fetchPage(page)
+		}()
+		it.next = next
+	}
+}
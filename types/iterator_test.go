@@ -0,0 +1,105 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// pager serves numbered items in pages from a fixed total
+type pager struct {
+	total int
+
+	mu    sync.Mutex
+	pages []int
+}
+
+func (p *pager) fetch(ctx context.Context, page, limit int) ([]int, MetaResponse, error) {
+	p.mu.Lock()
+	p.pages = append(p.pages, page)
+	p.mu.Unlock()
+
+	var items []int
+	for i := (page - 1) * limit; i < page*limit && i < p.total; i++ {
+		items = append(items, i)
+	}
+	totalPages := (p.total + limit - 1) / limit
+	return items, MetaResponse{Page: page, Limit: limit, TotalItems: p.total, TotalPages: totalPages}, nil
+}
+
+func (p *pager) fetched() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]int(nil), p.pages...)
+}
+
+func TestIterator(t *testing.T) {
+	t.Run("walks all pages", func(t *testing.T) {
+		p := &pager{total: 7}
+		items, err := NewIterator(context.Background(), p.fetch, WithPageSize(3)).All()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(items) != 7 || items[6] != 6 {
+			t.Errorf("expected items 0-6, got %v", items)
+		}
+		if pages := p.fetched(); len(pages) != 3 {
+			t.Errorf("expected 3 page requests, got %v", pages)
+		}
+	})
+
+	t.Run("stops at the item cap", func(t *testing.T) {
+		p := &pager{total: 100}
+		items, err := NewIterator(context.Background(), p.fetch, WithPageSize(10), WithMaxItems(15), WithPrefetch()).All()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(items) != 15 {
+			t.Errorf("expected 15 items, got %d", len(items))
+		}
+		if pages := p.fetched(); len(pages) != 2 {
+			t.Errorf("expected no page beyond the cap to be fetched, got %v", pages)
+		}
+	})
+
+	t.Run("prefetches the next page", func(t *testing.T) {
+		p := &pager{total: 20}
+		it := NewIterator(context.Background(), p.fetch, WithPageSize(10), WithPrefetch())
+		if !it.Next() {
+			t.Fatalf("unexpected end: %v", it.Err())
+		}
+		it.next <- <-it.next // wait for the prefetch without consuming it
+		if pages := p.fetched(); len(pages) != 2 {
+			t.Errorf("expected the second page to be prefetched, got %v", pages)
+		}
+		if items, _ := it.All(); len(items) != 19 {
+			t.Errorf("expected 19 remaining items, got %d", len(items))
+		}
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := &pager{total: 100}
+		it := NewIterator(ctx, p.fetch, WithPageSize(10))
+
+		it.Next()
+		cancel()
+		if it.Next() {
+			t.Error("expected the iteration to stop")
+		}
+		if !errors.Is(it.Err(), context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", it.Err())
+		}
+	})
+
+	t.Run("reports fetch errors", func(t *testing.T) {
+		failure := errors.New("boom")
+		it := NewIterator(context.Background(), func(ctx context.Context, page, limit int) ([]int, MetaResponse, error) {
+			return nil, MetaResponse{}, failure
+		})
+		if it.Next() || !errors.Is(it.Err(), failure) {
+			t.Errorf("expected the fetch error, got %v", it.Err())
+		}
+	})
+}